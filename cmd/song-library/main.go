@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"online-song-library/internal/config"
 	"online-song-library/internal/server"
 
@@ -32,6 +34,14 @@ func main() {
 		logrus.Fatal("Failed to retrieve env variables: ", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			logrus.Fatal("Migration failed: ", err)
+		}
+
+		return
+	}
+
 	if err := server.Run(cfg); err != nil {
 		logrus.Fatal("Error running service: ", err)
 	}
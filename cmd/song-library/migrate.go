@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"online-song-library/internal/bootstrap"
+	"online-song-library/internal/config"
+)
+
+var errMigrateUsage = errors.New("usage: song-library migrate up | down [steps] | status")
+
+// runMigrate handles "migrate up", "migrate down [steps]" and "migrate status".
+// down rolls back one migration unless told otherwise.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	ctx := context.Background()
+
+	pool, err := bootstrap.InitDB(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect postgres: %w", err)
+	}
+	defer pool.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := bootstrap.MigrateUp(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Applied %d migrations\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errMigrateUsage
+			}
+		}
+
+		rolledBack, err := bootstrap.MigrateDown(ctx, pool, steps)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Rolled back %d migrations\n", rolledBack)
+	case "status":
+		statuses, err := bootstrap.GetMigrationStatus(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Fprintf(w, "%s\t%s\n", status.ID, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
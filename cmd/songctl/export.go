@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/songio"
+)
+
+// exportSongs writes songs to a file or standard output. It ignores -o, the
+// export format is set with -format.
+func exportSongs(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", songio.FormatNDJSON, "csv, ndjson or json")
+	withVerses := flags.Bool("verses", false, "include the song text")
+	group := flags.String("group", "", "only songs of this group")
+	song := flags.String("song", "", "only songs with this name")
+	sortBy := flags.String("sort", "", "comma separated sort fields, prefix with - for descending")
+	path := flags.String("out", "-", `file to write, "-" for standard output`)
+	_ = flags.Parse(args)
+
+	filter := msong.Filter{}
+	if *group != "" {
+		filter = filter.And("group", msong.OpEq, *group)
+	}
+
+	if *song != "" {
+		filter = filter.And("song", msong.OpEq, *song)
+	}
+
+	sort, err := msong.ParseSort(*sortBy)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+
+	if *path != "-" {
+		file, err := os.Create(*path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	writer, err := songio.NewWriter(out, *format, *withVerses)
+	if err != nil {
+		return err
+	}
+
+	if err := app.songRepository.ExportSongs(ctx, filter, sort, *withVerses, writer.Write); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/songio"
+)
+
+// importSongs imports a CSV or NDJSON file, "-" reads standard input.
+func importSongs(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "csv or ndjson, by default taken from the file extension")
+	enrich := flags.Bool("enrich", false, "queue songs missing any detail for enrichment")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: give one file to import", errUsage)
+	}
+
+	path := flags.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	mediaType, ok := map[string]string{
+		"csv":    songio.MediaCSV,
+		"ndjson": songio.MediaNDJSON,
+		"jsonl":  songio.MediaNDJSON,
+	}[*format]
+	if !ok {
+		return fmt.Errorf("%w: unknown format %q, use -format csv or ndjson", errUsage, *format)
+	}
+
+	var in io.Reader = os.Stdin
+
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		in = file
+	}
+
+	reader, err := songio.NewReader(in, mediaType)
+	if err != nil {
+		return err
+	}
+
+	report, err := songio.Import(ctx, app.songRepository, reader, *enrich)
+	if err != nil {
+		return fmt.Errorf("import stopped after %d accepted rows: %w", report.Accepted, err)
+	}
+
+	return app.out.print(report, func(w io.Writer) {
+		fmt.Fprintf(w, "Accepted\t%d\n", report.Accepted)
+		fmt.Fprintf(w, "Rejected\t%d\n", report.Rejected)
+		fmt.Fprintf(w, "Duplicate\t%d\n", report.Duplicate)
+
+		for _, row := range report.Rows {
+			if row.Status != msong.ImportRejected {
+				continue
+			}
+
+			for _, fieldErr := range row.Errors {
+				fmt.Fprintf(w, "Row %d\t%s %s\n", row.Row, fieldErr.Field, fieldErr.Message)
+			}
+		}
+	})
+}
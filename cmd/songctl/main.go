@@ -0,0 +1,110 @@
+// Command songctl is an admin tool that works on the song library database
+// directly, without going through the HTTP API.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"online-song-library/internal/bootstrap"
+	"online-song-library/internal/config"
+	"online-song-library/internal/repository/songrepository"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: songctl [-o table|json|yaml] <command> [flags] [args]
+
+Commands:
+  list     [-group G] [-song S] [-sort FIELDS] [-offset N] [-limit N]
+  show     ID
+  create   -group G -song S [-release-date D] [-text T] [-link L]
+  update   ID [-group G] [-song S] [-release-date D] [-text T] [-link L] [-if-version N]
+  delete   ID
+  enrich   ID | -all [-status pending|ready|failed]
+  import   [-format csv|ndjson] [-enrich] FILE
+  export   [-format csv|ndjson|json] [-verses] [-group G] [-song S] [-sort FIELDS] [-out FILE]
+  stats
+
+Run "songctl <command> -h" for the flags of a command.
+`
+
+// command runs a subcommand with its arguments.
+type command func(ctx context.Context, app *app, args []string) error
+
+var commands = map[string]command{
+	"list":   listSongs,
+	"show":   showSong,
+	"create": createSong,
+	"update": updateSong,
+	"delete": deleteSong,
+	"enrich": enrichSongs,
+	"import": importSongs,
+	"export": exportSongs,
+	"stats":  printStats,
+}
+
+type app struct {
+	songRepository *songrepository.SongRepository
+	out            *output
+}
+
+func main() {
+	flags := flag.NewFlagSet("songctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	format := flags.String("o", formatTable, "output format: table, json or yaml")
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "songctl: unknown command %q\n\n", flags.Arg(0))
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	out, err := newOutput(os.Stdout, *format)
+	if err != nil {
+		fail(err)
+	}
+
+	if err := run(cmd, out, flags.Args()[1:]); err != nil {
+		fail(err)
+	}
+}
+
+func run(cmd command, out *output, args []string) error {
+	cfg := new(config.Config)
+
+	// Settings may come from the environment alone, .env is optional here.
+	_ = godotenv.Load()
+
+	if err := env.Parse(cfg); err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	ctx := context.Background()
+
+	pool, err := bootstrap.InitDB(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect postgres: %w", err)
+	}
+	defer pool.Close()
+
+	return cmd(ctx, &app{
+		songRepository: songrepository.NewSongRepository(pool),
+		out:            out,
+	}, args)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "songctl:", err)
+	os.Exit(1)
+}
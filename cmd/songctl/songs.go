@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+)
+
+var errUsage = errors.New("invalid arguments, see songctl -h")
+
+func listSongs(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	group := flags.String("group", "", "only songs of this group")
+	song := flags.String("song", "", "only songs with this name")
+	sortBy := flags.String("sort", "", "comma separated sort fields, prefix with - for descending")
+	offset := flags.Int("offset", 1, "1-based offset of the first song")
+	limit := flags.Int("limit", 50, "number of songs to list")
+	_ = flags.Parse(args)
+
+	filter := msong.Filter{}
+	if *group != "" {
+		filter = filter.And("group", msong.OpEq, *group)
+	}
+
+	if *song != "" {
+		filter = filter.And("song", msong.OpEq, *song)
+	}
+
+	sort, err := msong.ParseSort(*sortBy)
+	if err != nil {
+		return err
+	}
+
+	page, err := app.songRepository.GetPaginatedSongs(ctx, filter, msong.Page{
+		Offset: max(*offset, 1),
+		Limit:  max(*limit, 1),
+		Sort:   sort,
+	})
+	if err != nil {
+		return err
+	}
+
+	return app.out.print(page.Songs, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tGROUP\tSONG\tRELEASE DATE\tLINK")
+
+		for _, s := range page.Songs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.ID, s.Group, s.Song, s.ReleaseDate, s.Link)
+		}
+
+		fmt.Fprintf(w, "\n%d of %d songs\n", len(page.Songs), page.Total)
+	})
+}
+
+func showSong(ctx context.Context, app *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	details, err := app.songRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return app.out.print(details, func(w io.Writer) {
+		fmt.Fprintf(w, "ID\t%d\n", details.ID)
+		fmt.Fprintf(w, "Group\t%s\n", details.Group)
+		fmt.Fprintf(w, "Song\t%s\n", details.Song.Song)
+		fmt.Fprintf(w, "Release date\t%s\n", details.ReleaseDate)
+		fmt.Fprintf(w, "Link\t%s\n", details.Link)
+		fmt.Fprintf(w, "Verses\t%d\n", details.VerseCount)
+		fmt.Fprintf(w, "Enrichment\t%s\n", details.EnrichmentStatus)
+		fmt.Fprintf(w, "Version\t%d\n", details.Version)
+		fmt.Fprintf(w, "Created\t%s\n", details.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(w, "Updated\t%s\n", details.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+	})
+}
+
+// songFlags are the song fields shared by create and update.
+type songFlags struct {
+	flags       *flag.FlagSet
+	group       *string
+	song        *string
+	releaseDate *string
+	text        *string
+	link        *string
+}
+
+func newSongFlags(name string) *songFlags {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+
+	return &songFlags{
+		flags:       flags,
+		group:       flags.String("group", "", "group name"),
+		song:        flags.String("song", "", "song name"),
+		releaseDate: flags.String("release-date", "", "release date, DD.MM.YYYY or YYYY-MM-DD"),
+		text:        flags.String("text", "", `song text, verses separated by blank lines ("\n\n")`),
+		link:        flags.String("link", "", "link to the song"),
+	}
+}
+
+// set tells which flags were given on the command line.
+func (sf *songFlags) set() map[string]bool {
+	set := map[string]bool{}
+	sf.flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	return set
+}
+
+// createSong stores the song as given. Without any details it is queued for
+// enrichment, just like a song created through the API.
+func createSong(ctx context.Context, app *app, args []string) error {
+	sf := newSongFlags("create")
+	_ = sf.flags.Parse(args)
+
+	if *sf.group == "" || *sf.song == "" {
+		return fmt.Errorf("%w: -group and -song are required", errUsage)
+	}
+
+	song := msong.Song{
+		Group:       *sf.group,
+		Song:        *sf.song,
+		ReleaseDate: *sf.releaseDate,
+		Verses:      msong.SplitIntoVerses(*sf.text),
+		Link:        *sf.link,
+	}
+
+	if fieldErrs := msong.ValidateSong(song); len(fieldErrs) > 0 {
+		return &apperror.ValidationError{Fields: fieldErrs}
+	}
+
+	if song.ReleaseDate == "" && len(song.Verses) == 0 && song.Link == "" {
+		song.EnrichmentStatus = msong.EnrichmentPending
+	}
+
+	id, err := app.songRepository.Create(ctx, song)
+	if err != nil {
+		return err
+	}
+
+	song.ID = id
+
+	return app.out.print(song, func(w io.Writer) {
+		fmt.Fprintf(w, "Created song ID=%d\n", id)
+	})
+}
+
+// updateSong changes only the fields given on the command line.
+func updateSong(ctx context.Context, app *app, args []string) error {
+	sf := newSongFlags("update")
+	ifVersion := sf.flags.Int("if-version", 0, "fail unless the song still has this version")
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	_ = sf.flags.Parse(args[1:])
+
+	set := sf.set()
+	patch := msong.Patch{Version: *ifVersion}
+
+	if set["group"] {
+		patch.Group = sf.group
+	}
+
+	if set["song"] {
+		patch.Song = sf.song
+	}
+
+	if set["release-date"] {
+		patch.ReleaseDate = sf.releaseDate
+	}
+
+	if set["text"] {
+		verses := msong.SplitIntoVerses(*sf.text)
+		patch.Verses = &verses
+	}
+
+	if set["link"] {
+		patch.Link = sf.link
+	}
+
+	if fieldErrs := msong.ValidatePatch(patch); len(fieldErrs) > 0 {
+		return &apperror.ValidationError{Fields: fieldErrs}
+	}
+
+	version, err := app.songRepository.Patch(ctx, id, patch)
+	if err != nil {
+		return err
+	}
+
+	return app.out.print(map[string]any{"id": id, "version": version}, func(w io.Writer) {
+		fmt.Fprintf(w, "Updated song ID=%d, version %d\n", id, version)
+	})
+}
+
+func deleteSong(ctx context.Context, app *app, args []string) error {
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	if err := app.songRepository.Delete(ctx, msong.Song{ID: id}); err != nil {
+		return err
+	}
+
+	return app.out.print(map[string]any{"id": id}, func(w io.Writer) {
+		fmt.Fprintf(w, "Deleted song ID=%d\n", id)
+	})
+}
+
+// enrichSongs queues songs for enrichment again, the workers of a running
+// server pick them up.
+func enrichSongs(ctx context.Context, app *app, args []string) error {
+	flags := flag.NewFlagSet("enrich", flag.ExitOnError)
+	all := flags.Bool("all", false, "re-enrich all songs")
+	status := flags.String("status", "", "with -all, only songs in this enrichment status")
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+
+		if err := app.songRepository.RequeueEnrichment(ctx, id); err != nil {
+			return err
+		}
+
+		return app.out.print(map[string]any{"queued": 1}, func(w io.Writer) {
+			fmt.Fprintf(w, "Queued song ID=%d for enrichment\n", id)
+		})
+	}
+
+	_ = flags.Parse(args)
+
+	if !*all {
+		return fmt.Errorf("%w: give a song ID or -all", errUsage)
+	}
+
+	queued, err := app.songRepository.RequeueAllEnrichment(ctx, *status)
+	if err != nil {
+		return err
+	}
+
+	return app.out.print(map[string]any{"queued": queued}, func(w io.Writer) {
+		fmt.Fprintf(w, "Queued %d songs for enrichment\n", queued)
+	})
+}
+
+func printStats(ctx context.Context, app *app, _ []string) error {
+	stats, err := app.songRepository.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return app.out.print(stats, func(w io.Writer) {
+		fmt.Fprintf(w, "Songs\t%d\n", stats.Songs)
+		fmt.Fprintf(w, "Groups\t%d\n", stats.Groups)
+		fmt.Fprintf(w, "Verses\t%d\n", stats.Verses)
+		fmt.Fprintf(w, "Enrichment\t%d ready, %d pending, %d failed\n", stats.Ready, stats.Pending, stats.Failed)
+		fmt.Fprintf(w, "Queued jobs\t%d\n", stats.QueuedJobs)
+		fmt.Fprintf(w, "Released\t%s - %s\n", stats.EarliestRelease, stats.LatestRelease)
+	})
+}
+
+func parseID(args []string) (uint64, error) {
+	if len(args) == 0 {
+		return 0, fmt.Errorf("%w: song ID is required", errUsage)
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid song ID %q", errUsage, args[0])
+	}
+
+	return id, nil
+}
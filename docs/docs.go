@@ -20,9 +20,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/songs/": {
+        "/albums/": {
             "get": {
-                "description": "Retrieve a paginated list of songs based on optional query parameters.",
+                "description": "Retrieve albums by release date with their track counts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,26 +30,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Get  paginated list of songs",
+                "summary": "Get paginated list of albums",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Group name filter",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Song name filter",
-                        "name": "song",
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "artistId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Release date filter",
-                        "name": "releaseDate",
+                        "description": "Part of the album title",
+                        "name": "title",
                         "in": "query"
                     },
                     {
@@ -69,22 +63,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/song.Song"
-                            }
+                            "$ref": "#/definitions/handler.albumsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
                         }
                     },
                     "500": {
-                        "description": "failed to fetch songs",
+                        "description": "failed to fetch albums",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Add a new song to the system.",
+                "description": "Add an album. The artist is matched by name or alias, an unknown one is created.",
                 "consumes": [
                     "application/json"
                 ],
@@ -92,17 +89,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Create a new song",
+                "summary": "Create a new album",
                 "parameters": [
                     {
-                        "description": "group name and song name",
+                        "description": "album fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateSong.Request"
+                            "$ref": "#/definitions/handler.albumRequest"
                         }
                     }
                 ],
@@ -110,27 +107,45 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.createdResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new album"
+                            }
                         }
                     },
                     "400": {
                         "description": "invalid request body",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "artist already has an album with this title",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid album fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
                         }
                     },
                     "500": {
-                        "description": "failed to create song",
+                        "description": "failed to create album",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
                         }
                     }
                 }
             }
         },
-        "/songs/{id}": {
+        "/albums/{id}": {
             "get": {
-                "description": "Retrieve paginated text of a song by ID.",
+                "description": "Retrieve an album by ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -138,53 +153,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Get paginated song text",
+                "summary": "Get an album",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page offset (default 1)",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page (default 4, max 10)",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "text",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/album.Album"
                         }
                     },
                     "400": {
-                        "description": "invalid song ID",
+                        "description": "invalid album ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
                         }
                     },
                     "500": {
-                        "description": "failed to fetch text",
+                        "description": "failed to fetch album",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
                         }
                     }
                 }
             },
             "put": {
-                "description": "Update the details of a song by ID.",
+                "description": "Replace an album by ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,23 +201,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Update an existing song",
+                "summary": "Update an existing album",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "song fields",
+                        "description": "album fields",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateSong.Request"
+                            "$ref": "#/definitions/handler.albumRequest"
                         }
                     }
                 ],
@@ -222,19 +232,37 @@ const docTemplate = `{
                     "400": {
                         "description": "invalid request body",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "artist already has an album with this title",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid album fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
                         }
                     },
                     "500": {
-                        "description": "failed to update song",
+                        "description": "failed to update album",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Remove a song from the system by ID.",
+                "description": "Remove an album and its track list by ID, the songs are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -242,13 +270,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "albums"
                 ],
-                "summary": "Delete a song",
+                "summary": "Delete an album",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Song ID",
+                        "description": "Album ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -262,59 +290,2728 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "invalid song ID",
+                        "description": "invalid album ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
                         }
                     },
                     "500": {
-                        "description": "failed to delete song",
+                        "description": "failed to delete album",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.CreateSong.Request": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
+        },
+        "/albums/{id}/tracks": {
+            "get": {
+                "description": "Retrieve the songs of an album ordered by disc and track number.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Get album tracks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Album ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.tracksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid album ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch tracks",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
                 }
             }
         },
-        "handler.UpdateSong.Request": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
-                "link": {
-                    "type": "string"
-                },
-                "releaseDate": {
-                    "type": "string"
-                },
-                "song": {
-                    "type": "string"
-                },
-                "text": {
-                    "type": "string"
+        "/albums/{id}/tracks/{songId}": {
+            "put": {
+                "description": "Place a song on an album at a disc and track number, moving it if it is already there.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Put a song on an album",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Album ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "songId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "disc (default 1) and track number",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.trackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid album or song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album or song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "another song has this disc and track number",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid track fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to set track",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a song from the track list of an album, the song is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "albums"
+                ],
+                "summary": "Take a song off an album",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Album ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "songId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid album or song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "album not found or song is not on it",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to remove track",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
                 }
             }
         },
-        "song.Song": {
-            "type": "object",
-            "properties": {
-                "group": {
-                    "type": "string"
-                },
+        "/artists/": {
+            "get": {
+                "description": "Retrieve artists in sort name order with their song counts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Get paginated list of artists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of the artist name or of one of its aliases",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default 1)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.artistsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch artists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an artist. The sort name defaults to the name with a leading \"The\" moved to the end.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Create a new artist",
+                "parameters": [
+                    {
+                        "description": "artist fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.artistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.createdResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new artist"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "artist with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid artist fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create artist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/artists/{id}": {
+            "get": {
+                "description": "Retrieve an artist by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Get an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/artist.Artist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "artist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch artist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an artist by ID. A new name becomes the group of all the artist's songs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Update an existing artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "artist fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.artistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "artist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "artist with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid artist fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update artist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an artist that has no songs or albums by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Delete an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "artist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "artist still has songs or albums",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete artist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/artists/{id}/songs": {
+            "get": {
+                "description": "Retrieve the songs of an artist, paginated and sorted like the song listing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "artists"
+                ],
+                "summary": "Get paginated songs of an artist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Artist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default 1), ignored when cursor is set",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,song",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.songsResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid artist ID, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "artist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch songs",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/playlists/": {
+            "get": {
+                "description": "Retrieve playlists, the most recently changed first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Get paginated list of playlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner of the playlists",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default 1)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.playlistsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch playlists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an empty playlist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Create a new playlist",
+                "parameters": [
+                    {
+                        "description": "playlist fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.playlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.createdResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new playlist"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid playlist fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create playlist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/playlists/{id}": {
+            "get": {
+                "description": "Retrieve a playlist by ID with its items in order and the metadata of their songs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Get a playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/playlist.Details"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid playlist ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch playlist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name, owner and description of a playlist by ID, its items are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Update an existing playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "playlist fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.playlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid playlist fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update playlist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a playlist and its items by ID, the songs are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Delete a playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid playlist ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete playlist",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/items": {
+            "post": {
+                "description": "Put a song on a playlist before or after one of its items, at the end if neither is given. A song can be added more than once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Add a song to a playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "song and where to put it",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AddPlaylistItem.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID of the new item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.createdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid playlist ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist, song or neighbour item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "both before and after are set",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to add song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/items/{itemId}": {
+            "delete": {
+                "description": "Take an item off a playlist, the song is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Remove a playlist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid playlist or item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to remove item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/playlists/{id}/items/{itemId}/move": {
+            "post": {
+                "description": "Move an item right before or after another item of the playlist, to the end if neither is given. The other items keep their places.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playlists"
+                ],
+                "summary": "Move a playlist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "where to put the item",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.placementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid playlist or item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "playlist or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "both before and after are set",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to move item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/": {
+            "get": {
+                "description": "Retrieve a paginated list of songs based on optional query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Get  paginated list of songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name (exact match, group[contains|prefix|ilike] for patterns)",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song name (exact match, song[contains|prefix|ilike] for patterns)",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date, DD.MM.YYYY or YYYY-MM-DD",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after this date",
+                        "name": "releaseDateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before this date",
+                        "name": "releaseDateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link (exact match, link[contains|prefix|ilike] for patterns)",
+                        "name": "link",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags, songs with any of them (tags[all] for all of them)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default 1), ignored when cursor is set",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,group",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.songsResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch songs",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new song to the system. Details are fetched from the music info service in the background, track progress via enrichmentStatus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Create a new song",
+                "parameters": [
+                    {
+                        "description": "group name and song name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSong.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.createdResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "song conflicts with an existing one",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid song fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "503": {
+                        "description": "music info service is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/export": {
+            "get": {
+                "description": "Stream all songs matching the filters of GET /songs/ as CSV, NDJSON or a JSON array. The response is gzip-compressed when the client accepts it.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Export songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, ndjson (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the song text",
+                        "name": "verses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group name (exact match, group[contains|prefix|ilike] for patterns)",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song name (exact match, song[contains|prefix|ilike] for patterns)",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date, DD.MM.YYYY or YYYY-MM-DD",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after this date",
+                        "name": "releaseDateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before this date",
+                        "name": "releaseDateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link (exact match, link[contains|prefix|ilike] for patterns)",
+                        "name": "link",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags, songs with any of them (tags[all] for all of them)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,group",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "songs in the requested format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format, filter or sort",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to export songs",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/import": {
+            "post": {
+                "description": "Stream songs as CSV with a header row (group, song, releaseDate, text, link) or as NDJSON, one song per line. Each row is reported as accepted, rejected or duplicate; a row is a duplicate when an existing song or an earlier row has the same song name for the same artist, the group matched by name or alias like on create.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Import songs in bulk",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Queue accepted songs missing any detail for enrichment from the music info service",
+                        "name": "enrich",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/song.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "malformed input; report lists the rows stored before it",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to import songs; report lists the rows stored before the failure",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/search": {
+            "get": {
+                "description": "Full-text search over song verses. Results are ranked and list the matched verse numbers with highlighted snippets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Search songs by lyrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lyrics fragment, websearch syntax",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group name filter",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song name filter",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset (default 1)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/song.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "missing search query",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to search songs",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/tags": {
+            "get": {
+                "description": "Count the songs matching the filters per tag, for faceted navigation. Takes the same filters as the song listing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Count songs per tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name (exact match, group[contains|prefix|ilike] for patterns)",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Song name (exact match, song[contains|prefix|ilike] for patterns)",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date, DD.MM.YYYY or YYYY-MM-DD",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or after this date",
+                        "name": "releaseDateFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Released on or before this date",
+                        "name": "releaseDateTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link (exact match, link[contains|prefix|ilike] for patterns)",
+                        "name": "link",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags, songs with any of them (tags[all] for all of them)",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.tagCountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to count tags",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}": {
+            "get": {
+                "description": "Retrieve song metadata by ID without its text.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Get song metadata",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/song.Details"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "song version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the details of a song by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Update an existing song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "song fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateSong.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags the song must still have one of",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new song version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "412": {
+                        "description": "song version does not match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid song fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a song from the system by ID. The song is also taken off albums and playlists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Delete a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags the song must still have one of",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "412": {
+                        "description": "song version does not match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Apply a JSON Merge Patch (RFC 7396) to a song by ID. Only the supplied fields change, null clears the text.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Partially update a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "song fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateSong.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags the song must still have one of",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new song version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "412": {
+                        "description": "song version does not match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid song fields",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/tags": {
+            "post": {
+                "description": "Add tags to a song. Tags are matched case-insensitively, unknown ones are created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Tag a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "tags to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AttachTags.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "song version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid tags",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to tag song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/tags/{tag}": {
+            "delete": {
+                "description": "Remove a tag from a song, the tag is kept for other songs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Untag a song",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new song version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found or it has no such tag",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to untag song",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/verses": {
+            "get": {
+                "description": "Retrieve paginated verses of a song by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Get paginated song verses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Song ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Index of the first verse (default 1)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of verses per page (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.versesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid song ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "song not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to fetch verses",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/status/music-info": {
+            "get": {
+                "description": "Circuit breaker state and concurrency of calls to the external music info service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "status"
+                ],
+                "summary": "Music info service status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/infoservice.Status"
+                        }
+                    },
+                    "404": {
+                        "description": "music info service is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/handler.problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "album.Album": {
+            "type": "object",
+            "properties": {
+                "artist": {
+                    "type": "string"
+                },
+                "artistId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trackCount": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "album.Track": {
+            "type": "object",
+            "properties": {
+                "disc": {
+                    "type": "integer"
+                },
+                "enrichmentStatus": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "track": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version is bumped on every change. On writes a non-zero Version is the\nversion the client expects to overwrite.",
+                    "type": "integer"
+                }
+            }
+        },
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "artist.Artist": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "songCount": {
+                    "type": "integer"
+                },
+                "sortName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "circuitbreaker.State": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "StateClosed",
+                "StateOpen",
+                "StateHalfOpen"
+            ]
+        },
+        "circuitbreaker.Status": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/circuitbreaker.State"
+                }
+            }
+        },
+        "handler.AddPlaylistItem.Request": {
+            "type": "object",
+            "required": [
+                "songId"
+            ],
+            "properties": {
+                "after": {
+                    "type": "integer"
+                },
+                "before": {
+                    "type": "integer"
+                },
+                "songId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.AttachTags.Request": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.CreateSong.Request": {
+            "type": "object",
+            "required": [
+                "group",
+                "song"
+            ],
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "song": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.UpdateSong.Request": {
+            "type": "object",
+            "required": [
+                "group",
+                "link",
+                "releaseDate",
+                "song"
+            ],
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.albumRequest": {
+            "type": "object",
+            "required": [
+                "artist",
+                "title"
+            ],
+            "properties": {
+                "artist": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.albumsResponse": {
+            "type": "object",
+            "properties": {
+                "albums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/album.Album"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.artistRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sortName": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.artistsResponse": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/artist.Artist"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.createdResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.placementRequest": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer"
+                },
+                "before": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.playlistRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "owner"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "owner": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.playlistsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "playlists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/playlist.Playlist"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.problem": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "report": {
+                    "description": "Report lists the rows an import stored before it failed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/song.ImportReport"
+                        }
+                    ]
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.songsResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "songs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/song.Song"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.tagCountsResponse": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/song.TagCount"
+                    }
+                }
+            }
+        },
+        "handler.trackRequest": {
+            "type": "object",
+            "required": [
+                "track"
+            ],
+            "properties": {
+                "disc": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "track": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handler.tracksResponse": {
+            "type": "object",
+            "properties": {
+                "tracks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/album.Track"
+                    }
+                }
+            }
+        },
+        "handler.versesResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/song.Verse"
+                    }
+                }
+            }
+        },
+        "infoservice.Status": {
+            "type": "object",
+            "properties": {
+                "breaker": {
+                    "$ref": "#/definitions/circuitbreaker.Status"
+                },
+                "inFlight": {
+                    "type": "integer"
+                },
+                "maxConcurrent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "playlist.Details": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "itemCount": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/playlist.Item"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "playlist.Item": {
+            "type": "object",
+            "properties": {
+                "addedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "song": {
+                    "$ref": "#/definitions/song.Song"
+                }
+            }
+        },
+        "playlist.Playlist": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "itemCount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "song.Details": {
+            "type": "object",
+            "properties": {
+                "artistId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "enrichmentStatus": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "text": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verseCount": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version is bumped on every change. On writes a non-zero Version is the\nversion the client expects to overwrite.",
+                    "type": "integer"
+                }
+            }
+        },
+        "song.ImportReport": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "duplicate": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/song.ImportRow"
+                    }
+                }
+            }
+        },
+        "song.ImportRow": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "song.SearchResult": {
+            "type": "object",
+            "properties": {
+                "enrichmentStatus": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/song.VerseMatch"
+                    }
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "song": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "description": "Version is bumped on every change. On writes a non-zero Version is the\nversion the client expects to overwrite.",
+                    "type": "integer"
+                }
+            }
+        },
+        "song.Song": {
+            "type": "object",
+            "properties": {
+                "enrichmentStatus": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -332,6 +3029,43 @@ const docTemplate = `{
                     "items": {
                         "type": "string"
                     }
+                },
+                "version": {
+                    "description": "Version is bumped on every change. On writes a non-zero Version is the\nversion the client expects to overwrite.",
+                    "type": "integer"
+                }
+            }
+        },
+        "song.TagCount": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "songs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "song.Verse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "song.VerseMatch": {
+            "type": "object",
+            "properties": {
+                "snippet": {
+                    "type": "string"
+                },
+                "verse": {
+                    "type": "integer"
                 }
             }
         }
@@ -13,7 +13,9 @@ func InitDB(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
 		return nil, err
 	}
 
-	pgxCfg.MaxConns = int32(cfg.PgMaxOpenConn)
+	if cfg.PgMaxOpenConn > 0 {
+		pgxCfg.MaxConns = int32(cfg.PgMaxOpenConn)
+	}
 
 	dbPool, err := pgxpool.NewWithConfig(ctx, pgxCfg)
 	if err != nil {
@@ -0,0 +1,284 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"online-song-library/migrations"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// migrationsTable is shared with sql-migrate, so databases migrated with it
+// are picked up where they are.
+const migrationsTable = "migrations"
+
+// migrationLockKey names the advisory lock that serializes migration runs
+// across replicas.
+const migrationLockKey = "online-song-library/migrations"
+
+var errNoUpMigration = errors.New("no up migration")
+
+type migration struct {
+	id   string
+	up   string
+	down string
+}
+
+// MigrationStatus tells whether a migration has been applied and when.
+type MigrationStatus struct {
+	ID        string
+	AppliedAt *time.Time
+}
+
+// MigrateUp applies all pending migrations and returns how many were applied.
+func MigrateUp(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	applied := 0
+
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		pending, err := pendingMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range pending {
+			if err := applyMigration(ctx, conn, m.id, m.up, true); err != nil {
+				return err
+			}
+
+			logrus.Infof("Applied migration %s", m.id)
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// MigrateDown rolls back up to steps most recently applied migrations and
+// returns how many were rolled back.
+func MigrateDown(ctx context.Context, pool *pgxpool.Pool, steps int) (int, error) {
+	rolledBack := 0
+
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		all, err := loadMigrations()
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrationStatuses(ctx, conn, all)
+		if err != nil {
+			return err
+		}
+
+		for i := len(all) - 1; i >= 0 && rolledBack < steps; i-- {
+			if statuses[i].AppliedAt == nil {
+				continue
+			}
+
+			if err := applyMigration(ctx, conn, all[i].id, all[i].down, false); err != nil {
+				return err
+			}
+
+			logrus.Infof("Rolled back migration %s", all[i].id)
+			rolledBack++
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// GetMigrationStatus lists all known migrations in order.
+func GetMigrationStatus(ctx context.Context, pool *pgxpool.Pool) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		all, err := loadMigrations()
+		if err != nil {
+			return err
+		}
+
+		statuses, err = migrationStatuses(ctx, conn, all)
+
+		return err
+	})
+
+	return statuses, err
+}
+
+// withMigrationLock runs fn on a single connection holding the migration
+// advisory lock, after making sure the migrations table exists.
+func withMigrationLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgx.Conn) error) error {
+	poolConn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer poolConn.Release()
+
+	conn := poolConn.Conn()
+
+	if _, err := conn.Exec(ctx, "select pg_advisory_lock(hashtext($1))", migrationLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// The context may be canceled by now, the lock must be released anyway.
+		const unlockSQL = "select pg_advisory_unlock(hashtext($1))"
+
+		if _, err := conn.Exec(context.WithoutCancel(ctx), unlockSQL, migrationLockKey); err != nil {
+			logrus.Errorf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	const sql = `
+	create table if not exists ` + migrationsTable + ` (
+		id text not null primary key,
+		applied_at timestamptz
+	);
+	`
+
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("create migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func pendingMigrations(ctx context.Context, conn *pgx.Conn) ([]migration, error) {
+	all, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := migrationStatuses(ctx, conn, all)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []migration{}
+	for i, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, all[i])
+		}
+	}
+
+	return pending, nil
+}
+
+func migrationStatuses(ctx context.Context, conn *pgx.Conn, all []migration) ([]MigrationStatus, error) {
+	rows, err := conn.Query(ctx, "select id, applied_at from "+migrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[string]*time.Time{}
+	for rows.Next() {
+		var (
+			id        string
+			appliedAt *time.Time
+		)
+
+		if err := rows.Scan(&id, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		applied[id] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(all))
+	for _, m := range all {
+		status := MigrationStatus{ID: m.id}
+		if appliedAt, ok := applied[m.id]; ok {
+			if appliedAt == nil {
+				appliedAt = new(time.Time)
+			}
+
+			status.AppliedAt = appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// applyMigration runs the script and records the result in one transaction.
+func applyMigration(ctx context.Context, conn *pgx.Conn, id, script string, up bool) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		// The simple protocol allows several statements in one call.
+		if _, err := tx.Conn().PgConn().Exec(ctx, script).ReadAll(); err != nil {
+			return fmt.Errorf("migration %s: %w", id, err)
+		}
+
+		sql := "insert into " + migrationsTable + "(id, applied_at) values ($1, now())"
+		if !up {
+			sql = "delete from " + migrationsTable + " where id = $1"
+		}
+
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return fmt.Errorf("record migration %s: %w", id, err)
+		}
+
+		return nil
+	})
+}
+
+// loadMigrations reads the embedded sql-migrate files, ordered by name.
+func loadMigrations() ([]migration, error) {
+	names, err := fs.Glob(migrations.FS, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	all := make([]migration, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(migrations.FS, name)
+		if err != nil {
+			return nil, err
+		}
+
+		up, down, err := parseMigration(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		all = append(all, migration{
+			id:   name,
+			up:   up,
+			down: down,
+		})
+	}
+
+	return all, nil
+}
+
+// parseMigration splits a file into its "-- +migrate Up" and "-- +migrate Down"
+// parts. StatementBegin/End markers are comments and need no handling, since
+// the scripts run over the simple protocol.
+func parseMigration(text string) (up, down string, err error) {
+	_, rest, ok := strings.Cut(text, "-- +migrate Up")
+	if !ok {
+		return "", "", errNoUpMigration
+	}
+
+	up, down, _ = strings.Cut(rest, "-- +migrate Down")
+
+	return up, down, nil
+}
@@ -0,0 +1,52 @@
+package infoservice
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/pkg/lru"
+)
+
+// CacheEntry is a cached lookup result. NotFound entries remember that the
+// music info service does not know the song.
+type CacheEntry struct {
+	Detail   SongDetail
+	NotFound bool
+}
+
+// Cache stores lookup results by song key. Implementations must be safe for
+// concurrent use; a nil Cache disables caching.
+type Cache interface {
+	Get(ctx context.Context, key string) (CacheEntry, bool, error)
+	Set(ctx context.Context, key string, entry CacheEntry, ttl time.Duration) error
+}
+
+// CacheKey identifies a song regardless of letter case and surrounding spaces.
+func CacheKey(song msong.Song) string {
+	return strings.ToLower(strings.TrimSpace(song.Group)) + "\x00" + strings.ToLower(strings.TrimSpace(song.Song))
+}
+
+// MemoryCache keeps the most recently used entries in process memory.
+type MemoryCache struct {
+	entries *lru.Cache[string, CacheEntry]
+}
+
+func NewMemoryCache(size int) *MemoryCache {
+	return &MemoryCache{
+		entries: lru.New[string, CacheEntry](size),
+	}
+}
+
+func (mc *MemoryCache) Get(_ context.Context, key string) (CacheEntry, bool, error) {
+	entry, ok := mc.entries.Get(key)
+
+	return entry, ok, nil
+}
+
+func (mc *MemoryCache) Set(_ context.Context, key string, entry CacheEntry, ttl time.Duration) error {
+	mc.entries.Set(key, entry, ttl)
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package infoservice_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/config"
+	msong "online-song-library/internal/model/song"
+)
+
+// countingCache is a memory cache that counts lookups and can fail them.
+type countingCache struct {
+	*infoservice.MemoryCache
+	gets atomic.Int32
+	err  error
+}
+
+func (cc *countingCache) Get(ctx context.Context, key string) (infoservice.CacheEntry, bool, error) {
+	cc.gets.Add(1)
+
+	if cc.err != nil {
+		return infoservice.CacheEntry{}, false, cc.err
+	}
+
+	return cc.MemoryCache.Get(ctx, key)
+}
+
+func (cc *countingCache) Set(ctx context.Context, key string, entry infoservice.CacheEntry, ttl time.Duration) error {
+	if cc.err != nil {
+		return cc.err
+	}
+
+	return cc.MemoryCache.Set(ctx, key, entry, ttl)
+}
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b msong.Song
+		same bool
+	}{
+		{
+			name: "case and spaces",
+			a:    msong.Song{Group: "Muse", Song: "Hysteria"},
+			b:    msong.Song{Group: " MUSE ", Song: "hysteria "},
+			same: true,
+		},
+		{
+			name: "other song",
+			a:    msong.Song{Group: "Muse", Song: "Hysteria"},
+			b:    msong.Song{Group: "Muse", Song: "Uprising"},
+		},
+		{
+			name: "group and song are kept apart",
+			a:    msong.Song{Group: "a", Song: "bc"},
+			b:    msong.Song{Group: "ab", Song: "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := infoservice.CacheKey(tt.a) == infoservice.CacheKey(tt.b); got != tt.same {
+				t.Errorf("CacheKey(%+v) == CacheKey(%+v) is %t, want %t", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
+
+func TestGetSongInfoCachesDetails(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(replies(&calls))
+	defer server.Close()
+
+	client := newCachedClient(t, server, infoservice.NewMemoryCache(10), nil)
+
+	for _, lookup := range []msong.Song{song, {Group: " muse", Song: "SUPERMASSIVE BLACK HOLE"}} {
+		detail, err := client.GetSongInfo(context.Background(), lookup)
+		if err != nil {
+			t.Fatalf("GetSongInfo(%+v) error = %v", lookup, err)
+		}
+
+		if detail.ReleaseDate != "16.07.2006" || detail.Link != "https://example.com/muse" {
+			t.Errorf("GetSongInfo(%+v) = %+v", lookup, detail)
+		}
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server calls = %d, want 1", got)
+	}
+}
+
+func TestGetSongInfoCachesNotFound(t *testing.T) {
+	const negativeTTL = 50 * time.Millisecond
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(replies(&calls, http.StatusNotFound, http.StatusNotFound))
+	defer server.Close()
+
+	client := newCachedClient(t, server, infoservice.NewMemoryCache(10), func(cfg *config.Config) {
+		cfg.MusicInfoCacheNegativeTTL = negativeTTL
+	})
+
+	lookup := func(wantCalls int32) {
+		t.Helper()
+
+		if _, err := client.GetSongInfo(context.Background(), song); !errors.Is(err, infoservice.ErrSongNotFound) {
+			t.Errorf("GetSongInfo() error = %v, want %v", err, infoservice.ErrSongNotFound)
+		}
+
+		if got := calls.Load(); got != wantCalls {
+			t.Errorf("server calls = %d, want %d", got, wantCalls)
+		}
+	}
+
+	lookup(1)
+	lookup(1)
+
+	time.Sleep(2 * negativeTTL)
+
+	lookup(2)
+}
+
+func TestGetSongInfoMergesConcurrentLookups(t *testing.T) {
+	const callers = 10
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	serve := replies(&calls)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		serve(w, r)
+	}))
+	defer server.Close()
+
+	cache := &countingCache{MemoryCache: infoservice.NewMemoryCache(10)}
+	client := newCachedClient(t, server, cache, nil)
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Int32
+	)
+
+	for range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.GetSongInfo(context.Background(), song); err != nil {
+				failed.Add(1)
+			}
+		}()
+	}
+
+	// Hold the first lookup until every caller has missed the cache and
+	// joined it.
+	for cache.gets.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := failed.Load(); got != 0 {
+		t.Errorf("%d lookups failed", got)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server calls = %d, want 1", got)
+	}
+}
+
+func TestGetSongInfoCacheFailure(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(replies(&calls))
+	defer server.Close()
+
+	cache := &countingCache{MemoryCache: infoservice.NewMemoryCache(10), err: errors.New("cache down")}
+	client := newCachedClient(t, server, cache, nil)
+
+	for range 2 {
+		if _, err := client.GetSongInfo(context.Background(), song); err != nil {
+			t.Fatalf("GetSongInfo() error = %v", err)
+		}
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server calls = %d, want every lookup to reach the server", got)
+	}
+}
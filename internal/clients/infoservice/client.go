@@ -1,66 +1,283 @@
 package infoservice
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
+	"online-song-library/internal/apperror"
 	"online-song-library/internal/config"
 	msong "online-song-library/internal/model/song"
+	"online-song-library/pkg/circuitbreaker"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 )
 
+var (
+	ErrSongNotFound = apperror.New(apperror.ErrNotFound, "song not found in music info service")
+	ErrUnavailable  = apperror.New(apperror.ErrUpstreamUnavailable, "music info service unavailable")
+	ErrCircuitOpen  = apperror.New(apperror.ErrUpstreamUnavailable,
+		"music info service unavailable: circuit breaker is open")
+	ErrBusy = apperror.New(apperror.ErrUpstreamUnavailable,
+		"music info service unavailable: too many concurrent requests")
+)
+
+const maxRetryDelay = 10 * time.Second
+
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Client is safe for concurrent use. Results are cached and concurrent
+// lookups of the same song share one request. Requests go through a circuit
+// breaker and a bulkhead that caps the number of concurrent calls.
 type Client struct {
-	ReqURL     *url.URL
-	HTTPClient *http.Client
+	reqURL     url.URL
+	httpClient *http.Client
+	maxRetries int
+	retryDelay time.Duration
+	breaker    *circuitbreaker.Breaker
+	slots      chan struct{}
+
+	cache       Cache
+	cacheTTL    time.Duration
+	negativeTTL time.Duration
+	lookups     singleflight.Group
+}
+
+type Status struct {
+	Breaker       circuitbreaker.Status `json:"breaker"`
+	InFlight      int                   `json:"inFlight"`
+	MaxConcurrent int                   `json:"maxConcurrent"`
 }
 
-func NewMusicInfoClient(cfg *config.Config) *Client {
+func NewMusicInfoClient(cfg *config.Config, cache Cache) (*Client, error) {
 	reqURL, err := url.Parse(cfg.MusicInfoURL)
 	if err != nil {
-		logrus.Errorf("error parsing URL: %v\n", err)
+		return nil, fmt.Errorf("parse music info URL: %w", err)
 	}
 
 	return &Client{
-		ReqURL: reqURL,
-		HTTPClient: &http.Client{
-			Timeout: 10 * time.Second,
+		reqURL: *reqURL,
+		httpClient: &http.Client{
+			Timeout: cfg.MusicInfoTimeout,
 		},
+		maxRetries: cfg.MusicInfoMaxRetries,
+		retryDelay: cfg.MusicInfoRetryDelay,
+		breaker: circuitbreaker.New(circuitbreaker.Settings{
+			FailureThreshold: cfg.MusicInfoBreakerThreshold,
+			OpenTimeout:      cfg.MusicInfoBreakerTimeout,
+			HalfOpenMaxCalls: cfg.MusicInfoBreakerHalfOpenCalls,
+		}),
+		slots:       make(chan struct{}, max(cfg.MusicInfoMaxConcurrent, 1)),
+		cache:       cache,
+		cacheTTL:    cfg.MusicInfoCacheTTL,
+		negativeTTL: cfg.MusicInfoCacheNegativeTTL,
+	}, nil
+}
+
+func (c *Client) Status() Status {
+	return Status{
+		Breaker:       c.breaker.Status(),
+		InFlight:      len(c.slots),
+		MaxConcurrent: cap(c.slots),
 	}
 }
 
-func (c *Client) GetSongInfo(song msong.Song) (map[string]string, error) {
-	var response struct {
-		ReleaseDate string `json:"releaseDate"`
-		Text        string `json:"text"`
-		Link        string `json:"link"`
+// GetSongInfo returns song details from the cache or fetches them. Songs the
+// service does not know are cached too, for a shorter time.
+func (c *Client) GetSongInfo(ctx context.Context, song msong.Song) (*SongDetail, error) {
+	if c.cache == nil {
+		return c.lookup(ctx, song)
+	}
+
+	key := CacheKey(song)
+
+	entry, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		logrus.Warnf("music info cache lookup failed: %v", err)
 	}
 
-	query := c.ReqURL.Query()
+	if ok {
+		if entry.NotFound {
+			return nil, ErrSongNotFound
+		}
+
+		return &entry.Detail, nil
+	}
+
+	// The shared lookup must outlive the caller that started it, so it does
+	// not inherit its cancellation; every caller still stops waiting on its own.
+	ch := c.lookups.DoChan(key, func() (any, error) {
+		return c.lookupAndCache(context.WithoutCancel(ctx), key, song)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		detail := *res.Val.(*SongDetail)
+
+		return &detail, nil
+	}
+}
+
+func (c *Client) lookupAndCache(ctx context.Context, key string, song msong.Song) (*SongDetail, error) {
+	detail, err := c.lookup(ctx, song)
+	if err != nil && !errors.Is(err, ErrSongNotFound) {
+		return nil, err
+	}
+
+	entry, ttl := CacheEntry{NotFound: true}, c.negativeTTL
+	if err == nil {
+		entry, ttl = CacheEntry{Detail: *detail}, c.cacheTTL
+	}
+
+	if cacheErr := c.cache.Set(ctx, key, entry, ttl); cacheErr != nil {
+		logrus.Warnf("music info cache store failed: %v", cacheErr)
+	}
+
+	return detail, err
+}
+
+// lookup fetches song details, retrying timeouts and 5xx responses with
+// exponential backoff and jitter, or after the delay the server asks for in
+// Retry-After. It fails fast while the breaker is open or all slots are busy.
+func (c *Client) lookup(ctx context.Context, song msong.Song) (*SongDetail, error) {
+	select {
+	case c.slots <- struct{}{}:
+		defer func() { <-c.slots }()
+	default:
+		return nil, ErrBusy
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			return nil, ErrCircuitOpen
+		}
+
+		detail, retryAfter, err := c.fetch(ctx, song)
+		c.breaker.Record(!errors.Is(err, apperror.ErrUpstreamUnavailable))
+
+		if err == nil {
+			return detail, nil
+		}
+
+		if !errors.Is(err, ErrUnavailable) || attempt >= c.maxRetries {
+			return nil, err
+		}
+
+		delay := c.backoff(attempt)
+		if retryAfter > 0 {
+			delay = min(retryAfter, maxRetryDelay)
+		}
+
+		logrus.Warnf("music info request failed (attempt %d): %v, retrying in %s", attempt+1, err, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// fetch makes a single request. retryAfter is set when the server sent
+// a Retry-After header.
+func (c *Client) fetch(ctx context.Context, song msong.Song) (detail *SongDetail, retryAfter time.Duration, err error) {
+	reqURL := c.reqURL
+
+	query := reqURL.Query()
 	query.Set("group", song.Group)
 	query.Set("song", song.Song)
-	c.ReqURL.RawQuery = query.Encode()
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("build music info request: %w", err)
+	}
 
-	resp, err := c.HTTPClient.Get(c.ReqURL.String())
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		logrus.Errorf("error making GET request: %v\n", err)
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, 0, fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+
+		return nil, 0, apperror.New(apperror.ErrUpstreamUnavailable, err.Error())
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		logrus.Errorf("unexpected status code %d\n", resp.StatusCode)
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, 0, ErrSongNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("%w: unexpected status code %d", ErrUnavailable, resp.StatusCode)
+	default:
+		return nil, 0, apperror.New(apperror.ErrUpstreamUnavailable,
+			fmt.Sprintf("music info service responded with status code %d", resp.StatusCode))
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		logrus.Errorf("error decoding JSON response: %v\n", err)
+	detail = new(SongDetail)
+	if err := json.NewDecoder(resp.Body).Decode(detail); err != nil {
+		return nil, 0, apperror.New(apperror.ErrUpstreamUnavailable,
+			fmt.Sprintf("decode music info response: %v", err))
 	}
 
-	return map[string]string{
-		"ReleaseDate": response.ReleaseDate,
-		"Text":        response.Text,
-		"Link":        response.Link,
-	}, nil
+	return detail, 0, nil
+}
+
+// backoff returns a random delay up to retryDelay * 2^attempt ("full jitter"),
+// at most maxRetryDelay. Doubling stops at the cap, so it cannot overflow.
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.retryDelay
+	for i := 0; i < attempt && ceiling > 0 && ceiling < maxRetryDelay; i++ {
+		ceiling *= 2
+	}
+
+	ceiling = min(ceiling, maxRetryDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling) //nolint:gosec // jitter does not need a secure source
+}
+
+// parseRetryAfter reads Retry-After given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
 }
@@ -0,0 +1,418 @@
+package infoservice_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/config"
+	msong "online-song-library/internal/model/song"
+)
+
+var song = msong.Song{Group: "Muse", Song: "Supermassive Black Hole"}
+
+const detailJSON = `{"releaseDate":"16.07.2006","text":"Ooh baby","link":"https://example.com/muse"}`
+
+// newClient returns a client of the server without a cache, so every call
+// reaches the server. The breaker never opens within a single test.
+func newClient(t *testing.T, server *httptest.Server, modify func(*config.Config)) *infoservice.Client {
+	t.Helper()
+
+	return newCachedClient(t, server, nil, modify)
+}
+
+// newCachedClient returns a client of the server that keeps results in cache.
+func newCachedClient(
+	t *testing.T,
+	server *httptest.Server,
+	cache infoservice.Cache,
+	modify func(*config.Config),
+) *infoservice.Client {
+	t.Helper()
+
+	cfg := &config.Config{
+		MusicInfoURL:                  server.URL + "/info",
+		MusicInfoTimeout:              time.Second,
+		MusicInfoMaxRetries:           3,
+		MusicInfoRetryDelay:           time.Millisecond,
+		MusicInfoBreakerThreshold:     100,
+		MusicInfoBreakerTimeout:       time.Minute,
+		MusicInfoBreakerHalfOpenCalls: 1,
+		MusicInfoMaxConcurrent:        10,
+		MusicInfoCacheTTL:             time.Hour,
+		MusicInfoCacheNegativeTTL:     time.Hour,
+	}
+
+	if modify != nil {
+		modify(cfg)
+	}
+
+	client, err := infoservice.NewMusicInfoClient(cfg, cache)
+	if err != nil {
+		t.Fatalf("NewMusicInfoClient() error = %v", err)
+	}
+
+	return client
+}
+
+// replies answers with the given status codes in turn and with 200 and
+// a song detail once they run out. It counts the requests it served.
+func replies(calls *atomic.Int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+
+		if r.URL.Query().Get("group") != song.Group || r.URL.Query().Get("song") != song.Song {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(detailJSON))
+	}
+}
+
+func TestGetSongInfoRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			wantCalls: 1,
+		},
+		{
+			name:      "retry on 5xx",
+			statuses:  []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable},
+			wantCalls: 4,
+		},
+		{
+			name:      "retry on 429",
+			statuses:  []int{http.StatusTooManyRequests},
+			wantCalls: 2,
+		},
+		{
+			name:      "give up after the last retry",
+			statuses:  []int{500, 500, 500, 500, 500},
+			wantCalls: 4,
+			wantErr:   infoservice.ErrUnavailable,
+		},
+		{
+			name:      "no retry on 404",
+			statuses:  []int{http.StatusNotFound},
+			wantCalls: 1,
+			wantErr:   infoservice.ErrSongNotFound,
+		},
+		{
+			name:      "no retry on other 4xx",
+			statuses:  []int{http.StatusBadRequest},
+			wantCalls: 1,
+			wantErr:   apperror.ErrUpstreamUnavailable,
+		},
+		{
+			name:      "no retry on an unexpected status",
+			statuses:  []int{http.StatusNoContent},
+			wantCalls: 1,
+			wantErr:   apperror.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			server := httptest.NewServer(replies(&calls, tt.statuses...))
+			defer server.Close()
+
+			detail, err := newClient(t, server, nil).GetSongInfo(context.Background(), song)
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server got %d requests, want %d", got, tt.wantCalls)
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetSongInfo() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetSongInfo() error = %v", err)
+			}
+
+			if detail.ReleaseDate != "16.07.2006" || detail.Link != "https://example.com/muse" {
+				t.Errorf("GetSongInfo() = %+v", detail)
+			}
+		})
+	}
+}
+
+func TestGetSongInfoRetriesTimeouts(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+
+			return
+		}
+
+		_, _ = w.Write([]byte(detailJSON))
+	}))
+	defer server.Close()
+
+	client := newClient(t, server, func(cfg *config.Config) {
+		cfg.MusicInfoTimeout = 50 * time.Millisecond
+	})
+
+	if _, err := client.GetSongInfo(context.Background(), song); err != nil {
+		t.Fatalf("GetSongInfo() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+}
+
+func TestGetSongInfoHonoursRetryAfter(t *testing.T) {
+	var (
+		calls    atomic.Int32
+		mu       sync.Mutex
+		attempts []time.Time
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts = append(attempts, time.Now())
+		mu.Unlock()
+
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(detailJSON))
+	}))
+	defer server.Close()
+
+	if _, err := newClient(t, server, nil).GetSongInfo(context.Background(), song); err != nil {
+		t.Fatalf("GetSongInfo() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(attempts) != 2 {
+		t.Fatalf("server got %d requests, want 2", len(attempts))
+	}
+
+	if waited := attempts[1].Sub(attempts[0]); waited < 900*time.Millisecond {
+		t.Errorf("retried after %s, want about the 1s asked for in Retry-After", waited)
+	}
+}
+
+func TestGetSongInfoCancelDuringBackoff(t *testing.T) {
+	var calls atomic.Int32
+
+	first := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			defer close(first)
+		}
+
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-first
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+
+	_, err := newClient(t, server, nil).GetSongInfo(ctx, song)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSongInfo() error = %v, want %v", err, context.Canceled)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetSongInfo() returned after %s, want it to stop waiting on cancel", elapsed)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server got %d requests, want 1", got)
+	}
+}
+
+func TestGetSongInfoConcurrent(t *testing.T) {
+	const callers = 20
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(replies(&calls, http.StatusServiceUnavailable, http.StatusServiceUnavailable))
+	defer server.Close()
+
+	client := newClient(t, server, func(cfg *config.Config) {
+		cfg.MusicInfoMaxConcurrent = callers
+	})
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, callers)
+
+	for range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.GetSongInfo(context.Background(), song); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("GetSongInfo() error = %v", err)
+	}
+
+	if got := calls.Load(); got != callers+2 {
+		t.Errorf("server got %d requests, want %d", got, callers+2)
+	}
+
+	if status := client.Status(); status.InFlight != 0 {
+		t.Errorf("Status().InFlight = %d after all calls returned, want 0", status.InFlight)
+	}
+}
+
+func TestGetSongInfoBusy(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+
+		_, _ = w.Write([]byte(detailJSON))
+	}))
+	defer server.Close()
+
+	client := newClient(t, server, func(cfg *config.Config) {
+		cfg.MusicInfoMaxConcurrent = 1
+	})
+
+	done := make(chan error)
+
+	go func() {
+		_, err := client.GetSongInfo(context.Background(), song)
+		done <- err
+	}()
+
+	<-started
+
+	if _, err := client.GetSongInfo(context.Background(), song); !errors.Is(err, infoservice.ErrBusy) {
+		t.Errorf("GetSongInfo() error = %v, want %v", err, infoservice.ErrBusy)
+	}
+
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Errorf("GetSongInfo() error = %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{name: "absent"},
+		{name: "seconds", value: "3", wantMin: 3 * time.Second, wantMax: 3 * time.Second},
+		{name: "zero seconds", value: "0"},
+		{name: "negative seconds", value: "-5"},
+		{
+			name:    "HTTP date",
+			value:   time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat),
+			wantMin: 3 * time.Second,
+			wantMax: 5 * time.Second,
+		},
+		{name: "HTTP date in the past", value: "Wed, 21 Oct 2015 07:28:00 GMT"},
+		{name: "garbage", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infoservice.ParseRetryAfter(tt.value)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	const (
+		maxRetryDelay = 10 * time.Second
+		samples       = 20
+	)
+
+	server := httptest.NewServer(replies(new(atomic.Int32)))
+	defer server.Close()
+
+	for _, retryDelay := range []time.Duration{time.Millisecond, time.Second, 1 << 62} {
+		client := newClient(t, server, func(cfg *config.Config) {
+			cfg.MusicInfoRetryDelay = retryDelay
+		})
+
+		// Past the cap the full range up to it is used, never a wrapped
+		// around zero.
+		for _, attempt := range []int{20, 63, 64, 1000} {
+			longest := time.Duration(0)
+
+			for range samples {
+				got := client.Backoff(attempt)
+				if got < 0 || got >= maxRetryDelay {
+					t.Fatalf("Backoff(%d) with retry delay %s = %s, want within [0, %s)",
+						attempt, retryDelay, got, maxRetryDelay)
+				}
+
+				longest = max(longest, got)
+			}
+
+			if longest < maxRetryDelay/2 {
+				t.Errorf("Backoff(%d) with retry delay %s is at most %s, want up to %s",
+					attempt, retryDelay, longest, maxRetryDelay)
+			}
+		}
+	}
+}
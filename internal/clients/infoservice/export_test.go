@@ -0,0 +1,9 @@
+package infoservice
+
+import "time"
+
+var ParseRetryAfter = parseRetryAfter
+
+func (c *Client) Backoff(attempt int) time.Duration {
+	return c.backoff(attempt)
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // type Config struct {
 // 	Port string `env:"PORT"`
 
@@ -11,9 +13,44 @@ package config
 //		PgMaxOpenConn int    `env:"PG_MAX_OPEN_CONN"`
 //	}
 type Config struct {
-	Port          string `env:"PORT"`
-	MusicInfoURL  string `env:"MUSIC_INFO_URL"`
+	Port                string        `env:"PORT"`
+	MusicInfoURL        string        `env:"MUSIC_INFO_URL"`
+	MusicInfoTimeout    time.Duration `env:"MUSIC_INFO_TIMEOUT" envDefault:"10s"`
+	MusicInfoMaxRetries int           `env:"MUSIC_INFO_MAX_RETRIES" envDefault:"3"`
+	MusicInfoRetryDelay time.Duration `env:"MUSIC_INFO_RETRY_DELAY" envDefault:"200ms"`
+
+	MusicInfoBreakerThreshold     int           `env:"MUSIC_INFO_BREAKER_THRESHOLD" envDefault:"5"`
+	MusicInfoBreakerTimeout       time.Duration `env:"MUSIC_INFO_BREAKER_TIMEOUT" envDefault:"30s"`
+	MusicInfoBreakerHalfOpenCalls int           `env:"MUSIC_INFO_BREAKER_HALF_OPEN_CALLS" envDefault:"1"`
+	MusicInfoMaxConcurrent        int           `env:"MUSIC_INFO_MAX_CONCURRENT" envDefault:"10"`
+	// MusicInfoFallback is what CreateSong does while the breaker is open:
+	// "fail" responds with 503, "enrich" queues the song for enrichment anyway.
+	MusicInfoFallback string `env:"MUSIC_INFO_FALLBACK" envDefault:"fail"`
+	// MusicInfoCache selects where lookups are cached: "memory", "postgres" or "none".
+	MusicInfoCache            string        `env:"MUSIC_INFO_CACHE" envDefault:"memory"`
+	MusicInfoCacheSize        int           `env:"MUSIC_INFO_CACHE_SIZE" envDefault:"10000"`
+	MusicInfoCacheTTL         time.Duration `env:"MUSIC_INFO_CACHE_TTL" envDefault:"24h"`
+	MusicInfoCacheNegativeTTL time.Duration `env:"MUSIC_INFO_CACHE_NEGATIVE_TTL" envDefault:"1h"`
+
+	// MetadataProviders lists where song details come from, tried in order:
+	// "http" is the music info service, "fixture" reads MetadataFixtureDir.
+	// Case and spaces around the names do not matter.
+	MetadataProviders  []string `env:"METADATA_PROVIDERS" envSeparator:"," envDefault:"http"`
+	MetadataFixtureDir string   `env:"METADATA_FIXTURE_DIR" envDefault:"fixtures/metadata"`
+
+	EnrichmentWorkers      int           `env:"ENRICHMENT_WORKERS" envDefault:"2"`
+	EnrichmentPollInterval time.Duration `env:"ENRICHMENT_POLL_INTERVAL" envDefault:"1s"`
+	EnrichmentLease        time.Duration `env:"ENRICHMENT_LEASE" envDefault:"1m"`
+	EnrichmentMaxAttempts  int           `env:"ENRICHMENT_MAX_ATTEMPTS" envDefault:"5"`
+	EnrichmentRetryDelay   time.Duration `env:"ENRICHMENT_RETRY_DELAY" envDefault:"30s"`
+
+	// BulkTimeout is how long an import may take to upload or an export to
+	// download, instead of the usual server timeouts.
+	BulkTimeout time.Duration `env:"BULK_TIMEOUT" envDefault:"10m"`
+
 	LogLevel      int    `env:"LOG_LEVEL"`
 	PgDSN         string `env:"SONG_LIBRARY_PG_DSN"`
 	PgMaxOpenConn int    `env:"PG_MAX_OPEN_CONN"`
+	// MigrateOnStart applies pending migrations before the server starts.
+	MigrateOnStart bool `env:"MIGRATE_ON_START" envDefault:"false"`
 }
@@ -0,0 +1,3 @@
+package enrichment
+
+var Backoff = backoff
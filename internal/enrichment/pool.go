@@ -0,0 +1,166 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/config"
+	"online-song-library/internal/metadata"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/songrepository"
+
+	"github.com/sirupsen/logrus"
+)
+
+const maxRetryDelay = time.Hour
+
+// Pool runs workers that take enrichment jobs from the queue and fill in song
+// details from the metadata provider.
+type Pool struct {
+	songRepository *songrepository.SongRepository
+	provider       metadata.MetadataProvider
+
+	workers      int
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	retryDelay   time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewPool(
+	songRepository *songrepository.SongRepository,
+	provider metadata.MetadataProvider,
+	cfg *config.Config,
+) *Pool {
+	return &Pool{
+		songRepository: songRepository,
+		provider:       provider,
+		workers:        cfg.EnrichmentWorkers,
+		pollInterval:   cfg.EnrichmentPollInterval,
+		lease:          cfg.EnrichmentLease,
+		maxAttempts:    cfg.EnrichmentMaxAttempts,
+		retryDelay:     cfg.EnrichmentRetryDelay,
+		stop:           make(chan struct{}),
+	}
+}
+
+func (p *Pool) Start() {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+
+		go p.work()
+	}
+
+	logrus.Infof("Started %d enrichment workers", p.workers)
+}
+
+// Drain stops taking new jobs and waits for the ones in progress to finish
+// until ctx is done. Unfinished jobs are retried once their lease expires.
+func (p *Pool) Drain(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		if p.runOnce() {
+			continue
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// runOnce processes a single job and reports whether there was one. Jobs run
+// with their own context bounded by the lease, so draining lets them finish.
+func (p *Pool) runOnce() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), p.lease)
+	defer cancel()
+
+	jobs, err := p.songRepository.ClaimEnrichmentJobs(ctx, 1, p.lease)
+	if err != nil {
+		logrus.Errorf("enrichment: failed to claim jobs: %v", err)
+		return false
+	}
+
+	if len(jobs) == 0 {
+		return false
+	}
+
+	p.process(ctx, jobs[0])
+
+	return true
+}
+
+func (p *Pool) process(ctx context.Context, job msong.EnrichmentJob) {
+	detail, err := p.provider.GetSongInfo(ctx, job.Song)
+	if err == nil {
+		err = p.songRepository.CompleteEnrichmentJob(ctx, job, msong.Song{
+			ReleaseDate: detail.ReleaseDate,
+			Verses:      msong.SplitIntoVerses(detail.Text),
+			Link:        detail.Link,
+		})
+		if err == nil {
+			logrus.Infof("enrichment: song ID=%d enriched", job.Song.ID)
+			return
+		}
+	}
+
+	if errors.Is(err, infoservice.ErrSongNotFound) || job.Attempts >= p.maxAttempts {
+		logrus.Errorf("enrichment: giving up on song ID=%d after %d attempts: %v", job.Song.ID, job.Attempts, err)
+
+		if err := p.songRepository.FailEnrichmentJob(ctx, job); err != nil {
+			logrus.Errorf("enrichment: failed to mark song ID=%d failed: %v", job.Song.ID, err)
+		}
+
+		return
+	}
+
+	delay := backoff(p.retryDelay, job.Attempts)
+	logrus.Warnf("enrichment: attempt %d for song ID=%d failed: %v, retrying in %s", job.Attempts, job.Song.ID, err, delay)
+
+	if err := p.songRepository.RetryEnrichmentJob(ctx, job, time.Now().Add(delay), err); err != nil {
+		logrus.Errorf("enrichment: failed to reschedule song ID=%d: %v", job.Song.ID, err)
+	}
+}
+
+// backoff returns base doubled for every attempt after the first, capped at
+// maxRetryDelay. Doubling stops at the cap, so many attempts cannot overflow.
+func backoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay > 0 && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
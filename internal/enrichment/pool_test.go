@@ -0,0 +1,32 @@
+package enrichment_test
+
+import (
+	"testing"
+	"time"
+
+	"online-song-library/internal/enrichment"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", base: time.Minute, attempts: 1, want: time.Minute},
+		{name: "doubles", base: time.Minute, attempts: 4, want: 8 * time.Minute},
+		{name: "capped", base: time.Minute, attempts: 10, want: time.Hour},
+		{name: "many attempts do not overflow", base: time.Minute, attempts: 100, want: time.Hour},
+		{name: "huge base", base: 1 << 62, attempts: 3, want: time.Hour},
+		{name: "no delay", base: 0, attempts: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := enrichment.Backoff(tt.base, tt.attempts); got != tt.want {
+				t.Errorf("Backoff(%s, %d) = %s, want %s", tt.base, tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
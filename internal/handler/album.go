@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	malbum "online-song-library/internal/model/album"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// albumRequest is the body of CreateAlbum and UpdateAlbum.
+type albumRequest struct {
+	Title       string `json:"title" binding:"required,max=255"`
+	Artist      string `json:"artist" binding:"required,max=255"`
+	ReleaseDate string `json:"releaseDate" binding:"omitempty,releasedate"`
+	Link        string `json:"link" binding:"omitempty,httplink"`
+}
+
+// trackRequest is the body of SetAlbumTrack.
+type trackRequest struct {
+	Disc  int `json:"disc" binding:"omitempty,min=1"`
+	Track int `json:"track" binding:"required,min=1"`
+}
+
+// ListAlbums godoc
+// @Summary      Get paginated list of albums
+// @Description  Retrieve albums by release date with their track counts.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        artistId  query   uint64  false  "Artist ID"
+// @Param        title     query   string  false  "Part of the album title"
+// @Param        offset    query   int     false  "Page offset (default 1)"
+// @Param        limit     query   int     false  "Number of items per page (default 10, max 100)"
+// @Success      200 {object} handler.albumsResponse
+// @Failure      400 {object} handler.problem "invalid artist ID"
+// @Failure      500 {object} handler.problem "failed to fetch albums"
+// @Router       /albums/ [get]
+func (handler *Handler) ListAlbums(ctx *gin.Context) {
+	logrus.Debug("ListAlbums: received request")
+
+	var artistID uint64
+	if value, ok := ctx.GetQuery("artistId"); ok {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			logrus.Error("ListAlbums: invalid artist ID")
+			ctx.Error(errInvalidArtistID)
+			return
+		}
+
+		artistID = id
+	}
+
+	title := ctx.Query("title")
+	offset, limit := parsePagination(ctx)
+
+	logrus.Debugf("ListAlbums: artistId=%d, title=%q, offset=%d, limit=%d", artistID, title, offset, limit)
+
+	result, err := handler.service.ListAlbums(ctx, artistID, title, offset, limit)
+	if err != nil {
+		logrus.Errorf("ListAlbums: failed to fetch albums: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("ListAlbums: retrieved %d of %d albums", len(result.Albums), result.Total)
+
+	ctx.JSON(http.StatusOK, albumsResponse{
+		Albums: result.Albums,
+		Total:  result.Total,
+		Offset: offset,
+		Limit:  limit,
+	})
+}
+
+// GetAlbum godoc
+// @Summary      Get an album
+// @Description  Retrieve an album by ID.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id  path  uint64  true  "Album ID"
+// @Success      200 {object} album.Album
+// @Failure      400 {object} handler.problem "invalid album ID"
+// @Failure      404 {object} handler.problem "album not found"
+// @Failure      500 {object} handler.problem "failed to fetch album"
+// @Router       /albums/{id} [get]
+func (handler *Handler) GetAlbum(ctx *gin.Context) {
+	logrus.Debug("GetAlbum: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetAlbum: invalid album ID")
+		ctx.Error(errInvalidAlbumID)
+		return
+	}
+
+	album, err := handler.service.GetAlbum(ctx, id)
+	if err != nil {
+		logrus.Errorf("GetAlbum: failed to fetch album ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetAlbum: successfully fetched album ID=%d", id)
+
+	ctx.JSON(http.StatusOK, album)
+}
+
+// GetAlbumTracks godoc
+// @Summary      Get album tracks
+// @Description  Retrieve the songs of an album ordered by disc and track number.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id  path  uint64  true  "Album ID"
+// @Success      200 {object} handler.tracksResponse
+// @Failure      400 {object} handler.problem "invalid album ID"
+// @Failure      404 {object} handler.problem "album not found"
+// @Failure      500 {object} handler.problem "failed to fetch tracks"
+// @Router       /albums/{id}/tracks [get]
+func (handler *Handler) GetAlbumTracks(ctx *gin.Context) {
+	logrus.Debug("GetAlbumTracks: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetAlbumTracks: invalid album ID")
+		ctx.Error(errInvalidAlbumID)
+		return
+	}
+
+	tracks, err := handler.service.GetAlbumTracks(ctx, id)
+	if err != nil {
+		logrus.Errorf("GetAlbumTracks: failed to fetch tracks of album ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetAlbumTracks: retrieved %d tracks of album ID=%d", len(tracks), id)
+
+	ctx.JSON(http.StatusOK, tracksResponse{Tracks: tracks})
+}
+
+// CreateAlbum godoc
+// @Summary      Create a new album
+// @Description  Add an album. The artist is matched by name or alias, an unknown one is created.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.albumRequest true "album fields"
+// @Success      201 {object} handler.createdResponse "Created"
+// @Header       201 {string} Location "URL of the new album"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      409 {object} handler.problem "artist already has an album with this title"
+// @Failure      422 {object} handler.problem "invalid album fields"
+// @Failure      500 {object} handler.problem "failed to create album"
+// @Router       /albums/ [post]
+func (handler *Handler) CreateAlbum(ctx *gin.Context) {
+	logrus.Debug("CreateAlbum: received request")
+
+	var req albumRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("CreateAlbum: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	id, err := handler.service.CreateAlbum(ctx, malbum.Album{
+		Title:       req.Title,
+		Artist:      req.Artist,
+		ReleaseDate: req.ReleaseDate,
+		Link:        req.Link,
+	})
+	if err != nil {
+		logrus.Errorf("CreateAlbum: failed to create album, error=%v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("CreateAlbum: successfully created album ID=%d", id)
+	ctx.Header("Location", fmt.Sprintf("/albums/%d", id))
+	ctx.JSON(http.StatusCreated, createdResponse{ID: id})
+}
+
+// UpdateAlbum godoc
+// @Summary      Update an existing album
+// @Description  Replace an album by ID.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Album ID"
+// @Param        request body handler.albumRequest true "album fields"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      404 {object} handler.problem "album not found"
+// @Failure      409 {object} handler.problem "artist already has an album with this title"
+// @Failure      422 {object} handler.problem "invalid album fields"
+// @Failure      500 {object} handler.problem "failed to update album"
+// @Router       /albums/{id} [put]
+func (handler *Handler) UpdateAlbum(ctx *gin.Context) {
+	logrus.Debug("UpdateAlbum: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("UpdateAlbum: invalid album ID")
+		ctx.Error(errInvalidAlbumID)
+		return
+	}
+
+	var req albumRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("UpdateAlbum: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	if err := handler.service.UpdateAlbum(ctx, malbum.Album{
+		ID:          id,
+		Title:       req.Title,
+		Artist:      req.Artist,
+		ReleaseDate: req.ReleaseDate,
+		Link:        req.Link,
+	}); err != nil {
+		logrus.Errorf("UpdateAlbum: failed to update album ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("UpdateAlbum: successfully updated album ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// DeleteAlbum godoc
+// @Summary      Delete an album
+// @Description  Remove an album and its track list by ID, the songs are kept.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id   path   uint64  true   "Album ID"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid album ID"
+// @Failure      404 {object} handler.problem "album not found"
+// @Failure      500 {object} handler.problem "failed to delete album"
+// @Router       /albums/{id} [delete]
+func (handler *Handler) DeleteAlbum(ctx *gin.Context) {
+	logrus.Debug("DeleteAlbum: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("DeleteAlbum: invalid album ID")
+		ctx.Error(errInvalidAlbumID)
+		return
+	}
+
+	if err := handler.service.DeleteAlbum(ctx, id); err != nil {
+		logrus.Errorf("DeleteAlbum: failed to delete album ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("DeleteAlbum: successfully deleted album ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// SetAlbumTrack godoc
+// @Summary      Put a song on an album
+// @Description  Place a song on an album at a disc and track number, moving it if it is already there.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Album ID"
+// @Param        songId  path uint64 true "Song ID"
+// @Param        request body handler.trackRequest true "disc (default 1) and track number"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid album or song ID"
+// @Failure      404 {object} handler.problem "album or song not found"
+// @Failure      409 {object} handler.problem "another song has this disc and track number"
+// @Failure      422 {object} handler.problem "invalid track fields"
+// @Failure      500 {object} handler.problem "failed to set track"
+// @Router       /albums/{id}/tracks/{songId} [put]
+func (handler *Handler) SetAlbumTrack(ctx *gin.Context) {
+	logrus.Debug("SetAlbumTrack: received request")
+
+	albumID, songID, ok := parseTrackIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var req trackRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("SetAlbumTrack: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	track := malbum.Track{
+		Disc:  max(req.Disc, 1),
+		Track: req.Track,
+	}
+	track.ID = songID
+
+	if err := handler.service.SetAlbumTrack(ctx, albumID, track); err != nil {
+		logrus.Errorf("SetAlbumTrack: failed to set song ID=%d on album ID=%d: %v", songID, albumID, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("SetAlbumTrack: song ID=%d is track %d-%d of album ID=%d", songID, track.Disc, track.Track, albumID)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// RemoveAlbumTrack godoc
+// @Summary      Take a song off an album
+// @Description  Remove a song from the track list of an album, the song is kept.
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Album ID"
+// @Param        songId  path uint64 true "Song ID"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid album or song ID"
+// @Failure      404 {object} handler.problem "album not found or song is not on it"
+// @Failure      500 {object} handler.problem "failed to remove track"
+// @Router       /albums/{id}/tracks/{songId} [delete]
+func (handler *Handler) RemoveAlbumTrack(ctx *gin.Context) {
+	logrus.Debug("RemoveAlbumTrack: received request")
+
+	albumID, songID, ok := parseTrackIDs(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.service.RemoveAlbumTrack(ctx, albumID, songID); err != nil {
+		logrus.Errorf("RemoveAlbumTrack: failed to remove song ID=%d from album ID=%d: %v", songID, albumID, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("RemoveAlbumTrack: removed song ID=%d from album ID=%d", songID, albumID)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// parseTrackIDs reads the album and song IDs of a track route. On failure
+// the error is attached to ctx.
+func parseTrackIDs(ctx *gin.Context) (albumID, songID uint64, ok bool) {
+	albumID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("parseTrackIDs: invalid album ID")
+		ctx.Error(errInvalidAlbumID)
+		return 0, 0, false
+	}
+
+	songID, err = strconv.ParseUint(ctx.Param("songId"), 10, 64)
+	if err != nil {
+		logrus.Error("parseTrackIDs: invalid song ID")
+		ctx.Error(errInvalidSongID)
+		return 0, 0, false
+	}
+
+	return albumID, songID, true
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	martist "online-song-library/internal/model/artist"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// artistRequest is the body of CreateArtist and UpdateArtist.
+type artistRequest struct {
+	Name     string   `json:"name" binding:"required,max=255"`
+	SortName string   `json:"sortName" binding:"max=255"`
+	Aliases  []string `json:"aliases" binding:"max=50,dive,max=255"`
+}
+
+// ListArtists godoc
+// @Summary      Get paginated list of artists
+// @Description  Retrieve artists in sort name order with their song counts.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        name    query   string  false  "Part of the artist name or of one of its aliases"
+// @Param        offset  query   int     false  "Page offset (default 1)"
+// @Param        limit   query   int     false  "Number of items per page (default 10, max 100)"
+// @Success      200 {object} handler.artistsResponse
+// @Failure      500 {object} handler.problem "failed to fetch artists"
+// @Router       /artists/ [get]
+func (handler *Handler) ListArtists(ctx *gin.Context) {
+	logrus.Debug("ListArtists: received request")
+
+	name := ctx.Query("name")
+	offset, limit := parsePagination(ctx)
+
+	logrus.Debugf("ListArtists: name=%q, offset=%d, limit=%d", name, offset, limit)
+
+	result, err := handler.service.ListArtists(ctx, name, offset, limit)
+	if err != nil {
+		logrus.Errorf("ListArtists: failed to fetch artists: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("ListArtists: retrieved %d of %d artists", len(result.Artists), result.Total)
+
+	ctx.JSON(http.StatusOK, artistsResponse{
+		Artists: result.Artists,
+		Total:   result.Total,
+		Offset:  offset,
+		Limit:   limit,
+	})
+}
+
+// GetArtist godoc
+// @Summary      Get an artist
+// @Description  Retrieve an artist by ID.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id  path  uint64  true  "Artist ID"
+// @Success      200 {object} artist.Artist
+// @Failure      400 {object} handler.problem "invalid artist ID"
+// @Failure      404 {object} handler.problem "artist not found"
+// @Failure      500 {object} handler.problem "failed to fetch artist"
+// @Router       /artists/{id} [get]
+func (handler *Handler) GetArtist(ctx *gin.Context) {
+	logrus.Debug("GetArtist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetArtist: invalid artist ID")
+		ctx.Error(errInvalidArtistID)
+		return
+	}
+
+	artist, err := handler.service.GetArtist(ctx, id)
+	if err != nil {
+		logrus.Errorf("GetArtist: failed to fetch artist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetArtist: successfully fetched artist ID=%d", id)
+
+	ctx.JSON(http.StatusOK, artist)
+}
+
+// GetArtistSongs godoc
+// @Summary      Get paginated songs of an artist
+// @Description  Retrieve the songs of an artist, paginated and sorted like the song listing.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id      path    uint64  true   "Artist ID"
+// @Param        offset  query   int     false  "Page offset (default 1), ignored when cursor is set"
+// @Param        limit   query   int     false  "Number of items per page (default 10, max 100)"
+// @Param        sort    query   string  false  "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,song"
+// @Param        cursor  query   string  false  "Opaque cursor from nextCursor of the previous page"
+// @Success      200 {object} handler.songsResponse
+// @Header       200 {string} Link "RFC 8288 links to the first, prev, next and last pages"
+// @Failure      400 {object} handler.problem "invalid artist ID, sort or cursor"
+// @Failure      404 {object} handler.problem "artist not found"
+// @Failure      500 {object} handler.problem "failed to fetch songs"
+// @Router       /artists/{id}/songs [get]
+func (handler *Handler) GetArtistSongs(ctx *gin.Context) {
+	logrus.Debug("GetArtistSongs: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetArtistSongs: invalid artist ID")
+		ctx.Error(errInvalidArtistID)
+		return
+	}
+
+	page, err := parsePage(ctx)
+	if err != nil {
+		logrus.Errorf("GetArtistSongs: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	result, err := handler.service.GetArtistSongs(ctx, id, page)
+	if err != nil {
+		logrus.Errorf("GetArtistSongs: failed to fetch songs of artist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetArtistSongs: retrieved %d of %d songs of artist ID=%d", len(result.Songs), result.Total, id)
+
+	writeSongPage(ctx, page, result)
+}
+
+// CreateArtist godoc
+// @Summary      Create a new artist
+// @Description  Add an artist. The sort name defaults to the name with a leading "The" moved to the end.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.artistRequest true "artist fields"
+// @Success      201 {object} handler.createdResponse "Created"
+// @Header       201 {string} Location "URL of the new artist"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      409 {object} handler.problem "artist with this name already exists"
+// @Failure      422 {object} handler.problem "invalid artist fields"
+// @Failure      500 {object} handler.problem "failed to create artist"
+// @Router       /artists/ [post]
+func (handler *Handler) CreateArtist(ctx *gin.Context) {
+	logrus.Debug("CreateArtist: received request")
+
+	var req artistRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("CreateArtist: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	id, err := handler.service.CreateArtist(ctx, martist.Artist{
+		Name:     req.Name,
+		SortName: req.SortName,
+		Aliases:  req.Aliases,
+	})
+	if err != nil {
+		logrus.Errorf("CreateArtist: failed to create artist, error=%v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("CreateArtist: successfully created artist ID=%d", id)
+	ctx.Header("Location", fmt.Sprintf("/artists/%d", id))
+	ctx.JSON(http.StatusCreated, createdResponse{ID: id})
+}
+
+// UpdateArtist godoc
+// @Summary      Update an existing artist
+// @Description  Replace an artist by ID. A new name becomes the group of all the artist's songs.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Artist ID"
+// @Param        request body handler.artistRequest true "artist fields"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      404 {object} handler.problem "artist not found"
+// @Failure      409 {object} handler.problem "artist with this name already exists"
+// @Failure      422 {object} handler.problem "invalid artist fields"
+// @Failure      500 {object} handler.problem "failed to update artist"
+// @Router       /artists/{id} [put]
+func (handler *Handler) UpdateArtist(ctx *gin.Context) {
+	logrus.Debug("UpdateArtist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("UpdateArtist: invalid artist ID")
+		ctx.Error(errInvalidArtistID)
+		return
+	}
+
+	var req artistRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("UpdateArtist: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	if err := handler.service.UpdateArtist(ctx, martist.Artist{
+		ID:       id,
+		Name:     req.Name,
+		SortName: req.SortName,
+		Aliases:  req.Aliases,
+	}); err != nil {
+		logrus.Errorf("UpdateArtist: failed to update artist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("UpdateArtist: successfully updated artist ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// DeleteArtist godoc
+// @Summary      Delete an artist
+// @Description  Remove an artist that has no songs or albums by ID.
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id   path   uint64  true   "Artist ID"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid artist ID"
+// @Failure      404 {object} handler.problem "artist not found"
+// @Failure      409 {object} handler.problem "artist still has songs or albums"
+// @Failure      500 {object} handler.problem "failed to delete artist"
+// @Router       /artists/{id} [delete]
+func (handler *Handler) DeleteArtist(ctx *gin.Context) {
+	logrus.Debug("DeleteArtist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("DeleteArtist: invalid artist ID")
+		ctx.Error(errInvalidArtistID)
+		return
+	}
+
+	if err := handler.service.DeleteArtist(ctx, id); err != nil {
+		logrus.Errorf("DeleteArtist: failed to delete artist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("DeleteArtist: successfully deleted artist ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
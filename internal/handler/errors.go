@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidSongID     = apperror.New(apperror.ErrBadRequest, "invalid song ID")
+	errInvalidArtistID   = apperror.New(apperror.ErrBadRequest, "invalid artist ID")
+	errInvalidAlbumID    = apperror.New(apperror.ErrBadRequest, "invalid album ID")
+	errInvalidPlaylistID = apperror.New(apperror.ErrBadRequest, "invalid playlist ID")
+	errInvalidItemID     = apperror.New(apperror.ErrBadRequest, "invalid playlist item ID")
+	errInvalidBody       = apperror.New(apperror.ErrBadRequest, "invalid request body")
+	errMissingSearchTerm = apperror.New(apperror.ErrBadRequest, "missing search query")
+)
+
+// problem is an RFC 7807 problem details object.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Errors []apperror.FieldError `json:"errors,omitempty"`
+	// Report lists the rows an import stored before it failed.
+	Report *song.ImportReport `json:"report,omitempty"`
+}
+
+// partialImportError is an import that failed after storing the rows of its
+// report. It is rendered as the problem of err with the report.
+type partialImportError struct {
+	err    error
+	report *song.ImportReport
+}
+
+func (e *partialImportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialImportError) Unwrap() error {
+	return e.err
+}
+
+// errorHandler renders the last error attached to the context with ctx.Error
+// as application/problem+json. Details of internal errors are not exposed.
+func errorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		err := ctx.Errors.Last().Err
+		status := apperror.HTTPStatus(err)
+
+		response := problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Instance: ctx.Request.URL.Path,
+		}
+
+		if status != http.StatusInternalServerError {
+			response.Detail = err.Error()
+		}
+
+		var validationErr *apperror.ValidationError
+		if errors.As(err, &validationErr) {
+			response.Errors = validationErr.Fields
+		}
+
+		var importErr *partialImportError
+		if errors.As(err, &importErr) {
+			response.Report = importErr.report
+		}
+
+		ctx.Header("Content-Type", "application/problem+json")
+		ctx.JSON(status, response)
+	}
+}
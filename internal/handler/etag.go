@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidIfMatch = apperror.New(apperror.ErrBadRequest, "If-Match must be * or a list of entity tags")
+
+// etag renders a song version as a strong entity tag.
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion returns the song version required by the If-Match header,
+// 0 when the header is absent or "*". The header may list several tags.
+// If-Match uses strong comparison, so weak tags never match; when no tag
+// can match the song version the request fails with ErrVersionMismatch.
+// Of several candidate versions the current one of the song is used.
+func (handler *Handler) ifMatchVersion(ctx *gin.Context, id uint64) (int, error) {
+	value := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if value == "" {
+		return 0, nil
+	}
+
+	candidates := []int{}
+
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+
+		switch {
+		case tag == "*":
+			return 0, nil
+		case strings.HasPrefix(tag, `W/"`) && strings.HasSuffix(tag, `"`):
+			continue
+		case len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`):
+			return 0, errInvalidIfMatch
+		}
+
+		if version, err := strconv.Atoi(strings.Trim(tag, `"`)); err == nil && version > 0 {
+			candidates = append(candidates, version)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, msong.ErrVersionMismatch
+	case 1:
+		return candidates[0], nil
+	}
+
+	details, err := handler.service.GetSong(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if !slices.Contains(candidates, details.Version) {
+		return 0, msong.ErrVersionMismatch
+	}
+
+	return details.Version, nil
+}
+
+// notModified reports whether the If-None-Match header matches the current
+// version, in which case the client's copy is still fresh.
+func notModified(ctx *gin.Context, version int) bool {
+	value := ctx.GetHeader("If-None-Match")
+	if value == "" {
+		return false
+	}
+
+	current := etag(version)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/songio"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+var errInvalidVerses = apperror.New(apperror.ErrBadRequest, "verses must be true or false")
+
+// ExportSongs godoc
+// @Summary      Export songs
+// @Description  Stream all songs matching the filters of GET /songs/ as CSV, NDJSON or a JSON array. The response is gzip-compressed when the client accepts it.
+// @Tags         songs
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      json
+// @Param        format          query   string  false  "csv, ndjson (default) or json"
+// @Param        verses          query   bool    false  "Include the song text"
+// @Param        group           query   string  false  "Group name (exact match, group[contains|prefix|ilike] for patterns)"
+// @Param        song            query   string  false  "Song name (exact match, song[contains|prefix|ilike] for patterns)"
+// @Param        releaseDate     query   string  false  "Release date, DD.MM.YYYY or YYYY-MM-DD"
+// @Param        releaseDateFrom query   string  false  "Released on or after this date"
+// @Param        releaseDateTo   query   string  false  "Released on or before this date"
+// @Param        link            query   string  false  "Link (exact match, link[contains|prefix|ilike] for patterns)"
+// @Param        tags            query   string  false  "Comma separated tags, songs with any of them (tags[all] for all of them)"
+// @Param        sort            query   string  false  "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,group"
+// @Success      200 {string} string "songs in the requested format"
+// @Failure      400 {object} handler.problem "invalid format, filter or sort"
+// @Failure      500 {object} handler.problem "failed to export songs"
+// @Router       /songs/export [get]
+func (handler *Handler) ExportSongs(ctx *gin.Context) {
+	logrus.Debug("ExportSongs: received request")
+
+	format := ctx.DefaultQuery("format", songio.FormatNDJSON)
+
+	withVerses := false
+	if value := ctx.Query("verses"); value != "" {
+		var err error
+		if withVerses, err = strconv.ParseBool(value); err != nil {
+			ctx.Error(errInvalidVerses)
+			return
+		}
+	}
+
+	sort, err := msong.ParseSort(ctx.Query("sort"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	handler.extendDeadlines(ctx)
+
+	ctx.Header("Content-Type", songio.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="songs.%s"`, format))
+	ctx.Header("Vary", "Accept-Encoding")
+
+	var (
+		out io.Writer = ctx.Writer
+		gz  *gzipWriter
+	)
+
+	if acceptsGzip(ctx.GetHeader("Accept-Encoding")) {
+		ctx.Header("Content-Encoding", "gzip")
+
+		gz = &gzipWriter{w: ctx.Writer}
+		out = gz
+	}
+
+	writer, err := songio.NewWriter(out, format, withVerses)
+	if err != nil {
+		handler.exportError(ctx, err)
+		return
+	}
+
+	if err := handler.service.ExportSongs(ctx, parseSongFilter(ctx), sort, writer, withVerses); err != nil {
+		handler.exportError(ctx, err)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		logrus.Errorf("ExportSongs: failed to finish export: %v", err)
+		return
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			logrus.Errorf("ExportSongs: failed to finish export: %v", err)
+			return
+		}
+	}
+
+	logrus.Infof("ExportSongs: exported songs as %s", format)
+}
+
+// exportError reports err as a problem if nothing has been sent yet.
+// Otherwise the response is cut short, which clients see as a broken stream.
+func (handler *Handler) exportError(ctx *gin.Context, err error) {
+	logrus.Errorf("ExportSongs: %v", err)
+
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Encoding")
+	ctx.Writer.Header().Del("Content-Disposition")
+	ctx.Error(err)
+}
+
+// extendDeadlines lets bulk transfers run for bulkTimeout instead of the
+// server read and write timeouts.
+func (handler *Handler) extendDeadlines(ctx *gin.Context) {
+	deadline := time.Now().Add(handler.bulkTimeout)
+	rc := http.NewResponseController(ctx.Writer)
+
+	if err := rc.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logrus.Warnf("failed to extend read deadline: %v", err)
+	}
+
+	if err := rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logrus.Warnf("failed to extend write deadline: %v", err)
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip. gzip
+// or x-gzip listed with a weight above zero allows it, one listed with
+// q=0 refuses it, otherwise "*" decides. Codings are matched ignoring case,
+// members with an invalid weight are ignored.
+func acceptsGzip(header string) bool {
+	gzipWeight, anyWeight := -1.0, -1.0
+
+	for _, member := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(member, ";")
+
+		weight, ok := encodingWeight(params)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "gzip", "x-gzip":
+			gzipWeight = max(gzipWeight, weight)
+		case "*":
+			anyWeight = max(anyWeight, weight)
+		}
+	}
+
+	if gzipWeight >= 0 {
+		return gzipWeight > 0
+	}
+
+	return anyWeight > 0
+}
+
+// encodingWeight returns the q parameter among the parameters of an
+// Accept-Encoding member, 1 when there is none.
+func encodingWeight(params string) (float64, bool) {
+	weight := 1.0
+
+	for _, param := range strings.Split(params, ";") {
+		name, value, found := strings.Cut(param, "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+
+		// Negated so that NaN is rejected as well.
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || !(q >= 0 && q <= 1) {
+			return 0, false
+		}
+
+		weight = q
+	}
+
+	return weight, true
+}
+
+// gzipWriter compresses what is written to w. The gzip stream starts with
+// the first write, so an error reported before it goes out uncompressed.
+// It is closed only on success, a failed export must not end as a valid
+// gzip stream.
+type gzipWriter struct {
+	w  io.Writer
+	gz *gzip.Writer
+}
+
+func (gw *gzipWriter) Write(data []byte) (int, error) {
+	if gw.gz == nil {
+		gw.gz = gzip.NewWriter(gw.w)
+	}
+
+	return gw.gz.Write(data)
+}
+
+func (gw *gzipWriter) Close() error {
+	if gw.gz == nil {
+		gw.gz = gzip.NewWriter(gw.w)
+	}
+
+	return gw.gz.Close()
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"sort"
+
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin"
+)
+
+// filterFields lists the query parameters that can be used to filter songs.
+// A plain parameter (group=Muse) is an exact match, the bracketed form
+// (group[contains]=mus) selects an operator. For tags a plain parameter
+// (tags=rock,ballad) matches any of the tags, tags[all] all of them.
+var filterFields = []string{"group", "song", "releaseDate", "link", "tags"}
+
+func parseSongFilter(ctx *gin.Context) msong.Filter {
+	filter := msong.Filter{}
+
+	for _, field := range filterFields {
+		if value, ok := ctx.GetQuery(field); ok {
+			filter = filter.And(field, msong.OpEq, value)
+		}
+
+		ops := ctx.QueryMap(field)
+
+		keys := make([]string, 0, len(ops))
+		for op := range ops {
+			keys = append(keys, op)
+		}
+
+		sort.Strings(keys)
+
+		for _, op := range keys {
+			filter = filter.And(field, msong.Operator(op), ops[op])
+		}
+	}
+
+	if from, ok := ctx.GetQuery("releaseDateFrom"); ok {
+		filter = filter.And("releaseDate", msong.OpFrom, from)
+	}
+
+	if to, ok := ctx.GetQuery("releaseDateTo"); ok {
+		filter = filter.And("releaseDate", msong.OpTo, to)
+	}
+
+	return filter
+}
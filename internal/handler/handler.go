@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
-	"online-song-library/internal/service"
 	"strconv"
+	"strings"
+	"time"
 
 	msong "online-song-library/internal/model/song"
 
@@ -14,29 +16,77 @@ import (
 )
 
 type Handler struct {
-	service *service.Service
+	service     Service
+	bulkTimeout time.Duration
 }
 
-func NewHandler(service *service.Service) *Handler {
+func NewHandler(service Service, bulkTimeout time.Duration) *Handler {
 	return &Handler{
-		service: service,
+		service:     service,
+		bulkTimeout: bulkTimeout,
 	}
 }
 
 func (handler *Handler) InitRoutes() *gin.Engine {
+	registerValidators()
+
 	router := gin.New()
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(errorHandler())
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/status/music-info", handler.GetMusicInfoStatus)
 
 	songs := router.Group("/songs")
 	{
 		songs.GET("/", handler.GetPaginatedSongs)
-		songs.GET("/{id}", handler.GetPaginatedText)
+		songs.GET("/search", handler.SearchSongs)
+		songs.GET("/tags", handler.GetTagCounts)
+		songs.POST("/import", handler.ImportSongs)
+		songs.GET("/export", handler.ExportSongs)
+		songs.GET("/:id", handler.GetSong)
+		songs.GET("/:id/verses", handler.GetPaginatedVerses)
 		songs.POST("/", handler.CreateSong)
-		songs.PUT("/{id}", handler.UpdateSong)
-		songs.DELETE("/{id}", handler.DeleteSong)
+		songs.PUT("/:id", handler.UpdateSong)
+		songs.PATCH("/:id", handler.PatchSong)
+		songs.DELETE("/:id", handler.DeleteSong)
+		songs.POST("/:id/tags", handler.AttachTags)
+		songs.DELETE("/:id/tags/:tag", handler.DetachTag)
+	}
+
+	artists := router.Group("/artists")
+	{
+		artists.GET("/", handler.ListArtists)
+		artists.GET("/:id", handler.GetArtist)
+		artists.GET("/:id/songs", handler.GetArtistSongs)
+		artists.POST("/", handler.CreateArtist)
+		artists.PUT("/:id", handler.UpdateArtist)
+		artists.DELETE("/:id", handler.DeleteArtist)
+	}
+
+	albums := router.Group("/albums")
+	{
+		albums.GET("/", handler.ListAlbums)
+		albums.GET("/:id", handler.GetAlbum)
+		albums.GET("/:id/tracks", handler.GetAlbumTracks)
+		albums.POST("/", handler.CreateAlbum)
+		albums.PUT("/:id", handler.UpdateAlbum)
+		albums.DELETE("/:id", handler.DeleteAlbum)
+		albums.PUT("/:id/tracks/:songId", handler.SetAlbumTrack)
+		albums.DELETE("/:id/tracks/:songId", handler.RemoveAlbumTrack)
+	}
+
+	playlists := router.Group("/playlists")
+	{
+		playlists.GET("/", handler.ListPlaylists)
+		playlists.GET("/:id", handler.GetPlaylist)
+		playlists.POST("/", handler.CreatePlaylist)
+		playlists.PUT("/:id", handler.UpdatePlaylist)
+		playlists.DELETE("/:id", handler.DeletePlaylist)
+		playlists.POST("/:id/items", handler.AddPlaylistItem)
+		playlists.POST("/:id/items/:itemId/move", handler.MovePlaylistItem)
+		playlists.DELETE("/:id/items/:itemId", handler.RemovePlaylistItem)
 	}
 
 	return router
@@ -48,162 +98,232 @@ func (handler *Handler) InitRoutes() *gin.Engine {
 // @Tags         songs
 // @Accept       json
 // @Produce      json
-// @Param        group       query   string  false  "Group name filter"
-// @Param        song        query   string  false  "Song name filter"
-// @Param        releaseDate query   string  false  "Release date filter"
-// @Param        offset      query   int     false  "Page offset (default 1)"
-// @Param        limit       query   int     false  "Number of items per page (default 10, max 100)"
-// @Success      200 {array} song.Song
-// @Failure      500 {string} string "failed to fetch songs"
+// @Param        group           query   string  false  "Group name (exact match, group[contains|prefix|ilike] for patterns)"
+// @Param        song            query   string  false  "Song name (exact match, song[contains|prefix|ilike] for patterns)"
+// @Param        releaseDate     query   string  false  "Release date, DD.MM.YYYY or YYYY-MM-DD"
+// @Param        releaseDateFrom query   string  false  "Released on or after this date"
+// @Param        releaseDateTo   query   string  false  "Released on or before this date"
+// @Param        link            query   string  false  "Link (exact match, link[contains|prefix|ilike] for patterns)"
+// @Param        tags            query   string  false  "Comma separated tags, songs with any of them (tags[all] for all of them)"
+// @Param        offset          query   int     false  "Page offset (default 1), ignored when cursor is set"
+// @Param        limit           query   int     false  "Number of items per page (default 10, max 100)"
+// @Param        sort            query   string  false  "Comma separated sort fields, prefix with - for descending, e.g. -releaseDate,group"
+// @Param        cursor          query   string  false  "Opaque cursor from nextCursor of the previous page"
+// @Success      200 {object} handler.songsResponse
+// @Header       200 {string} Link "RFC 8288 links to the first, prev, next and last pages"
+// @Failure      400 {object} handler.problem "invalid filter, sort or cursor"
+// @Failure      500 {object} handler.problem "failed to fetch songs"
 // @Router       /songs/ [get]
 func (handler *Handler) GetPaginatedSongs(ctx *gin.Context) {
 	logrus.Debug("GetPaginatedSongs: received request")
 
-	fields := map[string]string{}
+	filter := parseSongFilter(ctx)
 
-	group, ok := ctx.GetQuery("group")
-	if ok {
-		fields["group"] = group
+	page, err := parsePage(ctx)
+	if err != nil {
+		logrus.Errorf("GetPaginatedSongs: %v", err)
+		ctx.Error(err)
+		return
 	}
 
-	song, ok := ctx.GetQuery("song")
-	if ok {
-		fields["song"] = song
-	}
+	logrus.Debugf("GetPaginatedSongs: filter=%v, sort=%v, offset=%d, limit=%d, cursor=%v",
+		filter, page.Sort, page.Offset, page.Limit, page.Cursor)
 
-	releaseDate, ok := ctx.GetQuery("releaseDate")
-	if ok {
-		fields["releaseDate"] = releaseDate
+	result, err := handler.service.GetPaginatedSongs(ctx, filter, page)
+	if err != nil {
+		logrus.Errorf("GetPaginatedSongs: failed to fetch songs: %v", err)
+		ctx.Error(err)
+		return
 	}
 
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "1"))
-	if offset < 1 {
-		offset = 1
-	}
+	logrus.Infof("GetPaginatedSongs: retrieved %d of %d songs", len(result.Songs), result.Total)
 
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if limit < 1 || limit > 100 {
-		limit = 10
+	writeSongPage(ctx, page, result)
+}
+
+// SearchSongs godoc
+// @Summary      Search songs by lyrics
+// @Description  Full-text search over song verses. Results are ranked and list the matched verse numbers with highlighted snippets.
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        q       query   string  true   "Lyrics fragment, websearch syntax"
+// @Param        group   query   string  false  "Group name filter"
+// @Param        song    query   string  false  "Song name filter"
+// @Param        offset  query   int     false  "Page offset (default 1)"
+// @Param        limit   query   int     false  "Number of items per page (default 10, max 100)"
+// @Success      200 {array} song.SearchResult
+// @Failure      400 {object} handler.problem "missing search query"
+// @Failure      500 {object} handler.problem "failed to search songs"
+// @Router       /songs/search [get]
+func (handler *Handler) SearchSongs(ctx *gin.Context) {
+	logrus.Debug("SearchSongs: received request")
+
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		logrus.Error("SearchSongs: missing search query")
+		ctx.Error(errMissingSearchTerm)
+		return
 	}
 
-	logrus.Debugf("GetPaginatedSongs: fields=%v, offset=%d, limit=%d",
-		fields, offset, limit)
+	filter := parseSongFilter(ctx)
+	offset, limit := parsePagination(ctx)
+
+	logrus.Debugf("SearchSongs: q=%q, filter=%v, offset=%d, limit=%d",
+		query, filter, offset, limit)
 
-	songs, err := handler.service.GetPaginatedSongs(ctx, fields, offset, limit)
+	results, err := handler.service.SearchSongs(ctx, query, filter, offset, limit)
 	if err != nil {
-		logrus.Errorf("GetPaginatedSongs: failed to fetch songs: %v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch songs",
-		})
+		logrus.Errorf("SearchSongs: failed to search songs: %v", err)
+		ctx.Error(err)
 		return
 	}
 
-	logrus.Infof("GetPaginatedSongs: retrieved %d songs", len(*songs))
+	logrus.Infof("SearchSongs: found %d songs", len(*results))
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"songs": songs,
+		"songs": results,
 	})
 }
 
-// GetPaginatedText godoc
-// @Summary      Get paginated song text
-// @Description  Retrieve paginated text of a song by ID.
+// GetSong godoc
+// @Summary      Get song metadata
+// @Description  Retrieve song metadata by ID without its text.
 // @Tags         songs
 // @Accept       json
 // @Produce      json
-// @Param        id     path   uint64  true   "Song ID"
-// @Param        offset query  int     false  "Page offset (default 1)"
-// @Param        limit  query  int     false  "Number of items per page (default 4, max 10)"
-// @Success      200 {string} string "text"
-// @Failure      400 {string} string "invalid song ID"
-// @Failure      500 {string} string "failed to fetch text"
+// @Param        id  path  uint64  true  "Song ID"
+// @Param        If-None-Match header string false "ETag of a cached copy"
+// @Success      200 {object} song.Details
+// @Header       200 {string} ETag "song version"
+// @Success      304 {string} string "Not modified"
+// @Failure      400 {object} handler.problem "invalid song ID"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      500 {object} handler.problem "failed to fetch song"
 // @Router       /songs/{id} [get]
-func (handler *Handler) GetPaginatedText(ctx *gin.Context) {
-	logrus.Debug("GetPaginatedText: received request")
+func (handler *Handler) GetSong(ctx *gin.Context) {
+	logrus.Debug("GetSong: received request")
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		logrus.Error("GetPaginatedText: invalid song ID")
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid song ID",
-		})
+		logrus.Error("GetSong: invalid song ID")
+		ctx.Error(errInvalidSongID)
+		return
 	}
 
-	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "1"))
-	if offset < 1 {
-		offset = 1
+	details, err := handler.service.GetSong(ctx, id)
+	if err != nil {
+		logrus.Errorf("GetSong: failed to fetch song ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("ETag", etag(details.Version))
+
+	if notModified(ctx, details.Version) {
+		logrus.Infof("GetSong: song ID=%d not modified", id)
+		ctx.Status(http.StatusNotModified)
+		return
 	}
 
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if limit < 1 || limit > 100 {
-		limit = 10
+	logrus.Infof("GetSong: successfully fetched song ID=%d", id)
+
+	ctx.JSON(http.StatusOK, details)
+}
+
+// GetPaginatedVerses godoc
+// @Summary      Get paginated song verses
+// @Description  Retrieve paginated verses of a song by ID.
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id     path   uint64  true   "Song ID"
+// @Param        offset query  int     false  "Index of the first verse (default 1)"
+// @Param        limit  query  int     false  "Number of verses per page (default 10, max 100)"
+// @Success      200 {object} handler.versesResponse
+// @Failure      400 {object} handler.problem "invalid song ID"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      500 {object} handler.problem "failed to fetch verses"
+// @Router       /songs/{id}/verses [get]
+func (handler *Handler) GetPaginatedVerses(ctx *gin.Context) {
+	logrus.Debug("GetPaginatedVerses: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetPaginatedVerses: invalid song ID")
+		ctx.Error(errInvalidSongID)
+		return
 	}
 
-	logrus.Debugf("GetPaginatedText: song ID=%d, offset=%d, limit=%d",
+	offset, limit := parsePagination(ctx)
+
+	logrus.Debugf("GetPaginatedVerses: song ID=%d, offset=%d, limit=%d",
 		id, offset, limit)
 
-	text, err := handler.service.GetPaginatedText(
+	verses, err := handler.service.GetPaginatedVerses(
 		ctx,
 		msong.Song{ID: id},
 		offset,
 		limit,
 	)
 	if err != nil {
-		logrus.Errorf("GetPaginatedText: failed to fetch text: %v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch text",
-		})
+		logrus.Errorf("GetPaginatedVerses: failed to fetch verses: %v", err)
+		ctx.Error(err)
 		return
 	}
 
-	logrus.Infof("GetPaginatedText: successfully fetched text for song ID=%d", id)
+	logrus.Infof("GetPaginatedVerses: successfully fetched %d verses for song ID=%d", len(verses), id)
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"text": text,
+	ctx.JSON(http.StatusOK, versesResponse{
+		Verses: verses,
+		Offset: offset,
+		Limit:  limit,
 	})
 }
 
 // CreateSong godoc
 // @Summary      Create a new song
-// @Description  Add a new song to the system.
+// @Description  Add a new song to the system. Details are fetched from the music info service in the background, track progress via enrichmentStatus.
 // @Tags         songs
 // @Accept       json
 // @Produce      json
 // @Param        request body handler.CreateSong.Request true "group name and song name"
-// @Success      201 {string} string "Created"
-// @Failure      400 {string} string "invalid request body"
-// @Failure      500 {string} string "failed to create song"
+// @Success      201 {object} handler.createdResponse "Created"
+// @Header       201 {string} Location "URL of the new song"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      409 {object} handler.problem "song conflicts with an existing one"
+// @Failure      422 {object} handler.problem "invalid song fields"
+// @Failure      500 {object} handler.problem "failed to create song"
+// @Failure      503 {object} handler.problem "music info service is unavailable"
 // @Router       /songs/ [post]
 func (handler *Handler) CreateSong(ctx *gin.Context) {
 	logrus.Debug("CreateSong: received request")
 
 	type Request struct {
-		Group string `json:"group"`
-		Song  string `json:"song"`
+		Group string `json:"group" binding:"required,max=255"`
+		Song  string `json:"song" binding:"required,max=255"`
 	}
 	var req Request
 
-	if err := ctx.BindJSON(&req); err != nil {
-		logrus.Error("CreateSong: invalid request body")
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid request body",
-		})
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("CreateSong: invalid request body: %v", err)
+		ctx.Error(bindError(err))
 		return
 	}
 
-	if err := handler.service.CreateSong(ctx, msong.Song{
+	id, err := handler.service.CreateSong(ctx, msong.Song{
 		Group: req.Group,
 		Song:  req.Song,
-	}); err != nil {
+	})
+	if err != nil {
 		logrus.Errorf("CreateSong: failed to create song, error=%v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to create song",
-		})
+		ctx.Error(err)
 		return
 	}
 
-	logrus.Infof("CreateSong: successfully created song")
-	ctx.JSON(http.StatusCreated, "")
+	logrus.Infof("CreateSong: successfully created song ID=%d", id)
+	ctx.Header("Location", fmt.Sprintf("/songs/%d", id))
+	ctx.JSON(http.StatusCreated, createdResponse{ID: id})
 }
 
 // UpdateSong godoc
@@ -213,71 +333,146 @@ func (handler *Handler) CreateSong(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id          path uint64 true  "Song ID"
-// @Param        request body handler.UpdateSong.Request false "song fields"
+// @Param        request body handler.UpdateSong.Request true "song fields"
+// @Param        If-Match header string false "ETags the song must still have one of"
 // @Success      204 {string} string "No content"
-// @Failure      400 {object} string "invalid request body"
-// @Failure      500 {object} string "failed to update song"
+// @Header       204 {string} ETag "new song version"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      412 {object} handler.problem "song version does not match"
+// @Failure      422 {object} handler.problem "invalid song fields"
+// @Failure      500 {object} handler.problem "failed to update song"
 // @Router       /songs/{id} [put]
 func (handler *Handler) UpdateSong(ctx *gin.Context) {
 	logrus.Debug("UpdateSong: received request")
 
 	type Request struct {
-		Group       string `json:"group"`
-		Song        string `json:"song"`
-		ReleaseDate string `json:"releaseDate"`
+		Group       string `json:"group" binding:"required,max=255"`
+		Song        string `json:"song" binding:"required,max=255"`
+		ReleaseDate string `json:"releaseDate" binding:"required,releasedate"`
 		Text        string `json:"text"`
-		Link        string `json:"link"`
+		Link        string `json:"link" binding:"required,httplink"`
 	}
 	var req Request
 
-	if err := ctx.BindJSON(&req); err != nil {
-		logrus.Error("UpdateSong: invalid request body")
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid request body",
-		})
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("UpdateSong: invalid request body: %v", err)
+		ctx.Error(bindError(err))
 		return
 	}
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
 		logrus.Error("UpdateSong: invalid ID parameter")
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid song ID",
-		})
+		ctx.Error(errInvalidSongID)
 		return
 	}
 
-	err = handler.service.UpdateSong(ctx, msong.Song{
+	expected, err := handler.ifMatchVersion(ctx, id)
+	if err != nil {
+		logrus.Errorf("UpdateSong: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	version, err := handler.service.UpdateSong(ctx, msong.Song{
 		ID:          id,
 		Group:       req.Group,
 		Song:        req.Song,
 		ReleaseDate: req.ReleaseDate,
 		Verses:      msong.SplitIntoVerses(req.Text),
 		Link:        req.Link,
+		Version:     expected,
 	})
 	if err != nil {
 		logrus.Errorf("UpdateSong: failed to update song ID=%d, error=%v", id, err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to update song",
-		})
+		ctx.Error(err)
 		return
 	}
 
 	logrus.Infof("UpdateSong: successfully updated song ID=%d", id)
 
+	ctx.Header("ETag", etag(version))
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// PatchSong godoc
+// @Summary      Partially update a song
+// @Description  Apply a JSON Merge Patch (RFC 7396) to a song by ID. Only the supplied fields change, null clears the text.
+// @Tags         songs
+// @Accept       json
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id      path uint64 true "Song ID"
+// @Param        request body handler.UpdateSong.Request true "song fields to change"
+// @Param        If-Match header string false "ETags the song must still have one of"
+// @Success      204 {string} string "No content"
+// @Header       204 {string} ETag "new song version"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      412 {object} handler.problem "song version does not match"
+// @Failure      422 {object} handler.problem "invalid song fields"
+// @Failure      500 {object} handler.problem "failed to update song"
+// @Router       /songs/{id} [patch]
+func (handler *Handler) PatchSong(ctx *gin.Context) {
+	logrus.Debug("PatchSong: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("PatchSong: invalid ID parameter")
+		ctx.Error(errInvalidSongID)
+		return
+	}
+
+	body, err := ctx.GetRawData()
+	if err != nil {
+		logrus.Errorf("PatchSong: failed to read request body: %v", err)
+		ctx.Error(errInvalidBody)
+		return
+	}
+
+	patch, err := parseMergePatch(body)
+	if err != nil {
+		logrus.Errorf("PatchSong: invalid request body: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	patch.Version, err = handler.ifMatchVersion(ctx, id)
+	if err != nil {
+		logrus.Errorf("PatchSong: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	version, err := handler.service.PatchSong(ctx, id, patch)
+	if err != nil {
+		logrus.Errorf("PatchSong: failed to update song ID=%d, error=%v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("PatchSong: successfully updated song ID=%d", id)
+
+	ctx.Header("ETag", etag(version))
+
 	ctx.JSON(http.StatusNoContent, "")
 }
 
 // DeleteSong godoc
 // @Summary      Delete a song
-// @Description  Remove a song from the system by ID.
+// @Description  Remove a song from the system by ID. The song is also taken off albums and playlists.
 // @Tags         songs
 // @Accept       json
 // @Produce      json
 // @Param        id   path   uint64  true   "Song ID"
+// @Param        If-Match header string false "ETags the song must still have one of"
 // @Success      204 {string} string "No content"
-// @Failure      400 {string} string "invalid song ID"
-// @Failure      500 {string} string "failed to delete song"
+// @Failure      400 {object} handler.problem "invalid song ID"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      412 {object} handler.problem "song version does not match"
+// @Failure      500 {object} handler.problem "failed to delete song"
 // @Router       /songs/{id} [delete]
 func (handler *Handler) DeleteSong(ctx *gin.Context) {
 	logrus.Debug("DeleteSong: received request")
@@ -285,17 +480,20 @@ func (handler *Handler) DeleteSong(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
 		logrus.Error("DeleteSong: invalid ID parameter")
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "invalid song ID",
-		})
+		ctx.Error(errInvalidSongID)
+		return
+	}
+
+	expected, err := handler.ifMatchVersion(ctx, id)
+	if err != nil {
+		logrus.Errorf("DeleteSong: %v", err)
+		ctx.Error(err)
 		return
 	}
 
-	if err := handler.service.DeleteSong(ctx, msong.Song{ID: id}); err != nil {
-		logrus.Errorf("DeleteSong: failed to delete song: %v", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to delete song",
-		})
+	if err := handler.service.DeleteSong(ctx, msong.Song{ID: id, Version: expected}); err != nil {
+		logrus.Errorf("DeleteSong: failed to delete song ID=%d: %v", id, err)
+		ctx.Error(err)
 		return
 	}
 
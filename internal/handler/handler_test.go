@@ -0,0 +1,760 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/handler"
+	"online-song-library/internal/handler/mocks"
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+var errDatabase = errors.New("connection refused")
+
+// handlerTest is a request to the router and the response it must get.
+// setup sets the calls the handler is expected to make to the service.
+type handlerTest struct {
+	name       string
+	method     string
+	target     string
+	body       string
+	header     map[string]string
+	setup      func(service *mocks.MockService)
+	wantStatus int
+	wantBody   string
+	wantHeader map[string]string
+}
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	logrus.SetOutput(io.Discard)
+
+	os.Exit(m.Run())
+}
+
+func runHandlerTests(t *testing.T, tests []handlerTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := mocks.NewMockService(gomock.NewController(t))
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.NewHandler(service, time.Minute).InitRoutes().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body, tt.wantBody)
+			}
+
+			for name, want := range tt.wantHeader {
+				if got := rec.Header().Get(name); got != want {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+var hysteria = msong.Details{
+	Song: msong.Song{
+		ID:          7,
+		Group:       "Muse",
+		Song:        "Hysteria",
+		ReleaseDate: "01.12.2003",
+		Link:        "https://example.com/hysteria",
+		Version:     3,
+	},
+}
+
+var nextCursor = msong.Cursor{Sort: "-releaseDate", Keys: []string{"2003-12-01"}, ID: 7}
+
+var getPaginatedSongsTests = []handlerTest{
+	{
+		name:   "filter and offset page",
+		method: http.MethodGet,
+		target: "/songs/?group=Muse&limit=2",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				GetPaginatedSongs(gomock.Any(), msong.Filter{}.And("group", msong.OpEq, "Muse"), msong.Page{
+					Offset: 1,
+					Limit:  2,
+				}).
+				Return(&msong.SongPage{Songs: []msong.Song{hysteria.Song}, Total: 3, HasNext: true}, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"total":3,"offset":1,"limit":2,"hasNext":true,"nextCursor":null`,
+		wantHeader: map[string]string{
+			"Link": `</songs/?group=Muse&limit=2&offset=1>; rel="first", ` +
+				`</songs/?group=Muse&limit=2&offset=3>; rel="next", ` +
+				`</songs/?group=Muse&limit=2&offset=3>; rel="last"`,
+		},
+	},
+	{
+		name:   "sort and cursor",
+		method: http.MethodGet,
+		target: "/songs/?sort=-releaseDate&cursor=" + nextCursor.Encode(),
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				GetPaginatedSongs(gomock.Any(), msong.Filter{}, msong.Page{
+					Offset: 1,
+					Limit:  10,
+					Sort:   msong.Sort{{Field: "releaseDate", Desc: true}},
+					Cursor: &nextCursor,
+				}).
+				Return(&msong.SongPage{Songs: []msong.Song{}, HasNext: true, Next: &nextCursor}, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"nextCursor":"` + nextCursor.Encode() + `"`,
+	},
+	{
+		name:       "invalid sort",
+		method:     http.MethodGet,
+		target:     "/songs/?sort=,",
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:       "invalid cursor",
+		method:     http.MethodGet,
+		target:     "/songs/?cursor=!!!",
+		wantStatus: http.StatusBadRequest,
+		wantBody:   msong.ErrInvalidCursor.Error(),
+	},
+	{
+		name:   "service error",
+		method: http.MethodGet,
+		target: "/songs/",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetPaginatedSongs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errDatabase)
+		},
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   `"title":"Internal Server Error"`,
+	},
+}
+
+func TestGetPaginatedSongs(t *testing.T) {
+	runHandlerTests(t, getPaginatedSongsTests)
+}
+
+var searchSongsTests = []handlerTest{
+	{
+		name:   "filter and page",
+		method: http.MethodGet,
+		target: "/songs/search?q=black+hole&group=Muse&offset=2&limit=5",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				SearchSongs(gomock.Any(), "black hole", msong.Filter{}.And("group", msong.OpEq, "Muse"), 2, 5).
+				Return(&[]msong.SearchResult{{Song: hysteria.Song, Rank: 0.5}}, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"songs":[{"id":7`,
+	},
+	{
+		name:   "out of range page falls back to the defaults",
+		method: http.MethodGet,
+		target: "/songs/search?q=hole&offset=-1&limit=500",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				SearchSongs(gomock.Any(), "hole", msong.Filter{}, 1, 10).
+				Return(&[]msong.SearchResult{}, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"songs":[]`,
+	},
+	{
+		name:       "missing query",
+		method:     http.MethodGet,
+		target:     "/songs/search?q=%20",
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "missing search query",
+	},
+	{
+		name:   "service error",
+		method: http.MethodGet,
+		target: "/songs/search?q=hole",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().SearchSongs(gomock.Any(), "hole", gomock.Any(), 1, 10).Return(nil, errDatabase)
+		},
+		wantStatus: http.StatusInternalServerError,
+	},
+}
+
+func TestSearchSongs(t *testing.T) {
+	runHandlerTests(t, searchSongsTests)
+}
+
+var getSongTests = []handlerTest{
+	{
+		name:   "found",
+		method: http.MethodGet,
+		target: "/songs/7",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(7)).Return(&hysteria, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"id":7,"group":"Muse","song":"Hysteria"`,
+		wantHeader: map[string]string{"ETag": `"3"`},
+	},
+	{
+		name:   "not modified",
+		method: http.MethodGet,
+		target: "/songs/7",
+		header: map[string]string{"If-None-Match": `"2", W/"3"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(7)).Return(&hysteria, nil)
+		},
+		wantStatus: http.StatusNotModified,
+		wantHeader: map[string]string{"ETag": `"3"`},
+	},
+	{
+		name:   "stale copy",
+		method: http.MethodGet,
+		target: "/songs/7",
+		header: map[string]string{"If-None-Match": `"2"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(7)).Return(&hysteria, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `"version":3`,
+	},
+	{
+		name:       "invalid ID",
+		method:     http.MethodGet,
+		target:     "/songs/-7",
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "invalid song ID",
+	},
+	{
+		name:   "not found",
+		method: http.MethodGet,
+		target: "/songs/8",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(8)).Return(nil, msong.ErrNotFound)
+		},
+		wantStatus: http.StatusNotFound,
+		wantBody:   "song not found",
+	},
+}
+
+func TestGetSong(t *testing.T) {
+	runHandlerTests(t, getSongTests)
+}
+
+var getPaginatedVersesTests = []handlerTest{
+	{
+		name:   "page of verses",
+		method: http.MethodGet,
+		target: "/songs/7/verses?offset=2&limit=1",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				GetPaginatedVerses(gomock.Any(), msong.Song{ID: 7}, 2, 1).
+				Return([]msong.Verse{{Index: 2, Text: "I want it now"}}, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `{"verses":[{"index":2,"text":"I want it now"}],"offset":2,"limit":1}`,
+	},
+	{
+		name:       "invalid ID",
+		method:     http.MethodGet,
+		target:     "/songs/seven/verses",
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "invalid song ID",
+	},
+	{
+		name:   "not found",
+		method: http.MethodGet,
+		target: "/songs/8/verses",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetPaginatedVerses(gomock.Any(), msong.Song{ID: 8}, 1, 10).Return(nil, msong.ErrNotFound)
+		},
+		wantStatus: http.StatusNotFound,
+	},
+}
+
+func TestGetPaginatedVerses(t *testing.T) {
+	runHandlerTests(t, getPaginatedVersesTests)
+}
+
+var createSongTests = []handlerTest{
+	{
+		name:   "created",
+		method: http.MethodPost,
+		target: "/songs/",
+		body:   `{"group":"Muse","song":"Hysteria"}`,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().CreateSong(gomock.Any(), msong.Song{Group: "Muse", Song: "Hysteria"}).Return(uint64(7), nil)
+		},
+		wantStatus: http.StatusCreated,
+		wantBody:   `{"id":7}`,
+		wantHeader: map[string]string{"Location": "/songs/7"},
+	},
+	{
+		name:       "missing song",
+		method:     http.MethodPost,
+		target:     "/songs/",
+		body:       `{"group":"Muse"}`,
+		wantStatus: http.StatusUnprocessableEntity,
+		wantBody:   `"errors":[{"field":"song","message":"is required"}]`,
+	},
+	{
+		name:       "group too long",
+		method:     http.MethodPost,
+		target:     "/songs/",
+		body:       `{"group":"` + strings.Repeat("a", 256) + `","song":"Hysteria"}`,
+		wantStatus: http.StatusUnprocessableEntity,
+		wantBody:   `"field":"group","message":"must be at most 255 characters long"`,
+	},
+	{
+		name:       "malformed body",
+		method:     http.MethodPost,
+		target:     "/songs/",
+		body:       `{"group":`,
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "invalid request body",
+	},
+	{
+		name:   "duplicate",
+		method: http.MethodPost,
+		target: "/songs/",
+		body:   `{"group":"Muse","song":"Hysteria"}`,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				CreateSong(gomock.Any(), gomock.Any()).
+				Return(uint64(0), apperror.New(apperror.ErrConflict, "song already exists"))
+		},
+		wantStatus: http.StatusConflict,
+	},
+	{
+		name:   "circuit open",
+		method: http.MethodPost,
+		target: "/songs/",
+		body:   `{"group":"Muse","song":"Hysteria"}`,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().CreateSong(gomock.Any(), gomock.Any()).Return(uint64(0), infoservice.ErrCircuitOpen)
+		},
+		wantStatus: http.StatusServiceUnavailable,
+		wantBody:   "circuit breaker is open",
+	},
+}
+
+func TestCreateSong(t *testing.T) {
+	runHandlerTests(t, createSongTests)
+}
+
+const updateBody = `{"group":"Muse","song":"Hysteria","releaseDate":"01.12.2003",` +
+	`"text":"It's bugging me\n\nI want it now","link":"https://example.com/hysteria"}`
+
+// updated is the song updateBody describes, expecting the given version.
+func updated(version int) msong.Song {
+	return msong.Song{
+		ID:          7,
+		Group:       "Muse",
+		Song:        "Hysteria",
+		ReleaseDate: "01.12.2003",
+		Verses:      []string{"It's bugging me", "I want it now"},
+		Link:        "https://example.com/hysteria",
+		Version:     version,
+	}
+}
+
+var updateSongTests = []handlerTest{
+	{
+		name:   "unconditional",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().UpdateSong(gomock.Any(), updated(0)).Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+		wantHeader: map[string]string{"ETag": `"4"`},
+	},
+	{
+		name:   "any version",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		header: map[string]string{"If-Match": "*"},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().UpdateSong(gomock.Any(), updated(0)).Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+	},
+	{
+		name:   "matching version",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		header: map[string]string{"If-Match": `"3"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().UpdateSong(gomock.Any(), updated(3)).Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+		wantHeader: map[string]string{"ETag": `"4"`},
+	},
+	{
+		name:   "list with the current version",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		header: map[string]string{"If-Match": `W/"4", "2", "3"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(7)).Return(&hysteria, nil)
+			service.EXPECT().UpdateSong(gomock.Any(), updated(3)).Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+	},
+	{
+		name:   "list without the current version",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		header: map[string]string{"If-Match": `"1", "2"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().GetSong(gomock.Any(), uint64(7)).Return(&hysteria, nil)
+		},
+		wantStatus: http.StatusPreconditionFailed,
+	},
+	{
+		name:       "weak tag never matches",
+		method:     http.MethodPut,
+		target:     "/songs/7",
+		body:       updateBody,
+		header:     map[string]string{"If-Match": `W/"3"`},
+		wantStatus: http.StatusPreconditionFailed,
+		wantBody:   msong.ErrVersionMismatch.Error(),
+	},
+	{
+		name:       "malformed tag",
+		method:     http.MethodPut,
+		target:     "/songs/7",
+		body:       updateBody,
+		header:     map[string]string{"If-Match": "3"},
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:   "stale version",
+		method: http.MethodPut,
+		target: "/songs/7",
+		body:   updateBody,
+		header: map[string]string{"If-Match": `"2"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().UpdateSong(gomock.Any(), updated(2)).Return(0, msong.ErrVersionMismatch)
+		},
+		wantStatus: http.StatusPreconditionFailed,
+	},
+	{
+		name:       "invalid fields",
+		method:     http.MethodPut,
+		target:     "/songs/7",
+		body:       `{"group":"Muse","song":"Hysteria","releaseDate":"2003-13-01","link":"ftp://example.com"}`,
+		wantStatus: http.StatusUnprocessableEntity,
+		wantBody:   `"field":"releaseDate"`,
+	},
+	{
+		name:       "invalid ID",
+		method:     http.MethodPut,
+		target:     "/songs/0x7",
+		body:       updateBody,
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:   "not found",
+		method: http.MethodPut,
+		target: "/songs/8",
+		body:   updateBody,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().UpdateSong(gomock.Any(), gomock.Any()).Return(0, msong.ErrNotFound)
+		},
+		wantStatus: http.StatusNotFound,
+	},
+}
+
+func TestUpdateSong(t *testing.T) {
+	runHandlerTests(t, updateSongTests)
+}
+
+var patchSongTests = []handlerTest{
+	{
+		name:   "changed fields only",
+		method: http.MethodPatch,
+		target: "/songs/7",
+		body:   `{"song":"Hysteria","text":null}`,
+		header: map[string]string{"Content-Type": "application/merge-patch+json"},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				PatchSong(gomock.Any(), uint64(7), msong.Patch{Song: ptr("Hysteria"), Verses: &[]string{}}).
+				Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+		wantHeader: map[string]string{"ETag": `"4"`},
+	},
+	{
+		name:   "matching version",
+		method: http.MethodPatch,
+		target: "/songs/7",
+		body:   `{"link":"https://example.com/muse"}`,
+		header: map[string]string{"If-Match": `"3"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				PatchSong(gomock.Any(), uint64(7), msong.Patch{Link: ptr("https://example.com/muse"), Version: 3}).
+				Return(4, nil)
+		},
+		wantStatus: http.StatusNoContent,
+	},
+	{
+		name:       "weak tag never matches",
+		method:     http.MethodPatch,
+		target:     "/songs/7",
+		body:       `{"song":"Hysteria"}`,
+		header:     map[string]string{"If-Match": `W/"3"`},
+		wantStatus: http.StatusPreconditionFailed,
+	},
+	{
+		name:       "required field cleared",
+		method:     http.MethodPatch,
+		target:     "/songs/7",
+		body:       `{"group":null}`,
+		wantStatus: http.StatusUnprocessableEntity,
+		wantBody:   `"field":"group","message":"is required"`,
+	},
+	{
+		name:       "unknown field",
+		method:     http.MethodPatch,
+		target:     "/songs/7",
+		body:       `{"album":"Absolution"}`,
+		wantStatus: http.StatusUnprocessableEntity,
+		wantBody:   `"field":"album","message":"is not a song field"`,
+	},
+	{
+		name:       "malformed body",
+		method:     http.MethodPatch,
+		target:     "/songs/7",
+		body:       `["song"]`,
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:       "invalid ID",
+		method:     http.MethodPatch,
+		target:     "/songs/seven",
+		body:       `{"song":"Hysteria"}`,
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:   "not found",
+		method: http.MethodPatch,
+		target: "/songs/8",
+		body:   `{"song":"Hysteria"}`,
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().PatchSong(gomock.Any(), uint64(8), gomock.Any()).Return(0, msong.ErrNotFound)
+		},
+		wantStatus: http.StatusNotFound,
+	},
+}
+
+func TestPatchSong(t *testing.T) {
+	runHandlerTests(t, patchSongTests)
+}
+
+var deleteSongTests = []handlerTest{
+	{
+		name:   "unconditional",
+		method: http.MethodDelete,
+		target: "/songs/7",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().DeleteSong(gomock.Any(), msong.Song{ID: 7}).Return(nil)
+		},
+		wantStatus: http.StatusNoContent,
+	},
+	{
+		name:   "matching version",
+		method: http.MethodDelete,
+		target: "/songs/7",
+		header: map[string]string{"If-Match": `"3"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().DeleteSong(gomock.Any(), msong.Song{ID: 7, Version: 3}).Return(nil)
+		},
+		wantStatus: http.StatusNoContent,
+	},
+	{
+		name:       "weak tag never matches",
+		method:     http.MethodDelete,
+		target:     "/songs/7",
+		header:     map[string]string{"If-Match": `W/"3"`},
+		wantStatus: http.StatusPreconditionFailed,
+	},
+	{
+		name:   "stale version",
+		method: http.MethodDelete,
+		target: "/songs/7",
+		header: map[string]string{"If-Match": `"2"`},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().DeleteSong(gomock.Any(), msong.Song{ID: 7, Version: 2}).Return(msong.ErrVersionMismatch)
+		},
+		wantStatus: http.StatusPreconditionFailed,
+	},
+	{
+		name:       "invalid ID",
+		method:     http.MethodDelete,
+		target:     "/songs/seven",
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:   "not found",
+		method: http.MethodDelete,
+		target: "/songs/8",
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().DeleteSong(gomock.Any(), msong.Song{ID: 8}).Return(msong.ErrNotFound)
+		},
+		wantStatus: http.StatusNotFound,
+	},
+}
+
+func TestDeleteSong(t *testing.T) {
+	runHandlerTests(t, deleteSongTests)
+}
+
+var partialReport = &msong.ImportReport{
+	Accepted: 1,
+	Rows:     []msong.ImportRow{{Row: 1, ID: 7, Status: msong.ImportAccepted}},
+}
+
+var importSongsTests = []handlerTest{
+	{
+		name:   "report",
+		method: http.MethodPost,
+		target: "/songs/import?enrich=true",
+		body:   "group,song\nMuse,Hysteria\n",
+		header: map[string]string{"Content-Type": "text/csv"},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().ImportSongs(gomock.Any(), gomock.Any(), true).Return(partialReport, nil)
+		},
+		wantStatus: http.StatusOK,
+		wantBody:   `{"accepted":1,"rejected":0,"duplicate":0,"rows":[{"row":1,`,
+	},
+	{
+		name:   "failure keeps the partial report",
+		method: http.MethodPost,
+		target: "/songs/import",
+		body:   "group,song\nMuse,Hysteria\n",
+		header: map[string]string{"Content-Type": "text/csv"},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().ImportSongs(gomock.Any(), gomock.Any(), false).Return(partialReport, errDatabase)
+		},
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   `"report":{"accepted":1,"rejected":0,"duplicate":0,"rows":[{"row":1,`,
+	},
+	{
+		name:   "failure without a report",
+		method: http.MethodPost,
+		target: "/songs/import",
+		body:   "group,song\nMuse,Hysteria\n",
+		header: map[string]string{"Content-Type": "text/csv"},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().ImportSongs(gomock.Any(), gomock.Any(), false).Return(nil, errDatabase)
+		},
+		wantStatus: http.StatusInternalServerError,
+		wantBody:   `"status":500,"instance":"/songs/import"}`,
+	},
+	{
+		name:       "invalid enrich",
+		method:     http.MethodPost,
+		target:     "/songs/import?enrich=maybe",
+		body:       "group,song\n",
+		header:     map[string]string{"Content-Type": "text/csv"},
+		wantStatus: http.StatusBadRequest,
+	},
+	{
+		name:       "unsupported content type",
+		method:     http.MethodPost,
+		target:     "/songs/import",
+		body:       "<songs/>",
+		header:     map[string]string{"Content-Type": "application/xml"},
+		wantStatus: http.StatusUnsupportedMediaType,
+	},
+}
+
+func TestImportSongs(t *testing.T) {
+	runHandlerTests(t, importSongsTests)
+}
+
+// exportEncodingTest exports songs with the Accept-Encoding header value
+// and expects the response to be compressed or not.
+func exportEncodingTest(name, acceptEncoding string, compressed bool) handlerTest {
+	encoding := ""
+	if compressed {
+		encoding = "gzip"
+	}
+
+	return handlerTest{
+		name:   name,
+		method: http.MethodGet,
+		target: "/songs/export?format=csv",
+		header: map[string]string{"Accept-Encoding": acceptEncoding},
+		setup: func(service *mocks.MockService) {
+			service.EXPECT().
+				ExportSongs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).
+				Return(nil)
+		},
+		wantStatus: http.StatusOK,
+		wantHeader: map[string]string{"Content-Encoding": encoding, "Vary": "Accept-Encoding"},
+	}
+}
+
+var exportSongsTests = []handlerTest{
+	exportEncodingTest("no Accept-Encoding", "", false),
+	exportEncodingTest("gzip", "gzip", true),
+	exportEncodingTest("gzip among others", "br;q=1.0, GZIP;q=0.5, deflate", true),
+	exportEncodingTest("x-gzip", "x-gzip", true),
+	exportEncodingTest("gzip refused", "gzip;q=0", false),
+	exportEncodingTest("gzip refused with spaces", "br, gzip ; q=0.000", false),
+	exportEncodingTest("wildcard", "*", true),
+	exportEncodingTest("wildcard refused", "*;q=0", false),
+	exportEncodingTest("gzip refused despite wildcard", "*, gzip;q=0", false),
+	exportEncodingTest("gzip despite refused wildcard", "gzip, *;q=0", true),
+	exportEncodingTest("token containing gzip", "gzipped, nogzip", false),
+	exportEncodingTest("invalid weight is ignored", "gzip;q=2", false),
+	exportEncodingTest("identity only", "identity", false),
+	{
+		name:       "invalid verses",
+		method:     http.MethodGet,
+		target:     "/songs/export?verses=maybe",
+		wantStatus: http.StatusBadRequest,
+	},
+}
+
+func TestExportSongs(t *testing.T) {
+	runHandlerTests(t, exportSongsTests)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/songio"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+var errInvalidEnrich = apperror.New(apperror.ErrBadRequest, "enrich must be true or false")
+
+// ImportSongs godoc
+// @Summary      Import songs in bulk
+// @Description  Stream songs as CSV with a header row (group, song, releaseDate, text, link) or as NDJSON, one song per line. Each row is reported as accepted, rejected or duplicate; a row is a duplicate when an existing song or an earlier row has the same song name for the same artist, the group matched by name or alias like on create.
+// @Tags         songs
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        enrich query bool false "Queue accepted songs missing any detail for enrichment from the music info service"
+// @Success      200 {object} song.ImportReport
+// @Failure      400 {object} handler.problem "malformed input; report lists the rows stored before it"
+// @Failure      415 {object} handler.problem "unsupported content type"
+// @Failure      500 {object} handler.problem "failed to import songs; report lists the rows stored before the failure"
+// @Router       /songs/import [post]
+func (handler *Handler) ImportSongs(ctx *gin.Context) {
+	logrus.Debug("ImportSongs: received request")
+
+	handler.extendDeadlines(ctx)
+
+	enrich := false
+	if value := ctx.Query("enrich"); value != "" {
+		var err error
+		if enrich, err = strconv.ParseBool(value); err != nil {
+			ctx.Error(errInvalidEnrich)
+			return
+		}
+	}
+
+	reader, err := songio.NewReader(ctx.Request.Body, ctx.ContentType())
+	if err != nil {
+		logrus.Errorf("ImportSongs: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	report, err := handler.service.ImportSongs(ctx, reader, enrich)
+	if err != nil && report == nil {
+		logrus.Errorf("ImportSongs: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	if err != nil {
+		logrus.Errorf("ImportSongs: import stopped after %d accepted rows: %v", report.Accepted, err)
+		ctx.Error(&partialImportError{err: err, report: report})
+		return
+	}
+
+	logrus.Infof("ImportSongs: accepted %d, rejected %d, duplicate %d rows",
+		report.Accepted, report.Rejected, report.Duplicate)
+
+	ctx.JSON(http.StatusOK, report)
+}
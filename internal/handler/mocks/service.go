@@ -0,0 +1,1199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	infoservice "online-song-library/internal/clients/infoservice"
+	album "online-song-library/internal/model/album"
+	artist "online-song-library/internal/model/artist"
+	playlist "online-song-library/internal/model/playlist"
+	song "online-song-library/internal/model/song"
+	songio "online-song-library/internal/songio"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPlaylistItem mocks base method.
+func (m *MockService) AddPlaylistItem(ctx context.Context, id, songID uint64, placement playlist.Placement) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistItem", ctx, id, songID, placement)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistItem indicates an expected call of AddPlaylistItem.
+func (mr *MockServiceMockRecorder) AddPlaylistItem(ctx, id, songID, placement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistItem", reflect.TypeOf((*MockService)(nil).AddPlaylistItem), ctx, id, songID, placement)
+}
+
+// AttachTags mocks base method.
+func (m *MockService) AttachTags(ctx context.Context, id uint64, names []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachTags", ctx, id, names)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachTags indicates an expected call of AttachTags.
+func (mr *MockServiceMockRecorder) AttachTags(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachTags", reflect.TypeOf((*MockService)(nil).AttachTags), ctx, id, names)
+}
+
+// CreateAlbum mocks base method.
+func (m *MockService) CreateAlbum(ctx context.Context, a album.Album) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, a)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockServiceMockRecorder) CreateAlbum(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockService)(nil).CreateAlbum), ctx, a)
+}
+
+// CreateArtist mocks base method.
+func (m *MockService) CreateArtist(ctx context.Context, a artist.Artist) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, a)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockServiceMockRecorder) CreateArtist(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockService)(nil).CreateArtist), ctx, a)
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockService) CreatePlaylist(ctx context.Context, p playlist.Playlist) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, p)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockServiceMockRecorder) CreatePlaylist(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockService)(nil).CreatePlaylist), ctx, p)
+}
+
+// CreateSong mocks base method.
+func (m *MockService) CreateSong(ctx context.Context, s song.Song) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSong", ctx, s)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSong indicates an expected call of CreateSong.
+func (mr *MockServiceMockRecorder) CreateSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSong", reflect.TypeOf((*MockService)(nil).CreateSong), ctx, s)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockService) DeleteAlbum(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockServiceMockRecorder) DeleteAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockService)(nil).DeleteAlbum), ctx, id)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockService) DeleteArtist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockServiceMockRecorder) DeleteArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockService)(nil).DeleteArtist), ctx, id)
+}
+
+// DeletePlaylist mocks base method.
+func (m *MockService) DeletePlaylist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockServiceMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*MockService)(nil).DeletePlaylist), ctx, id)
+}
+
+// DeleteSong mocks base method.
+func (m *MockService) DeleteSong(ctx context.Context, s song.Song) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSong", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSong indicates an expected call of DeleteSong.
+func (mr *MockServiceMockRecorder) DeleteSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSong", reflect.TypeOf((*MockService)(nil).DeleteSong), ctx, s)
+}
+
+// DetachTag mocks base method.
+func (m *MockService) DetachTag(ctx context.Context, id uint64, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachTag", ctx, id, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachTag indicates an expected call of DetachTag.
+func (mr *MockServiceMockRecorder) DetachTag(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachTag", reflect.TypeOf((*MockService)(nil).DetachTag), ctx, id, name)
+}
+
+// ExportSongs mocks base method.
+func (m *MockService) ExportSongs(ctx context.Context, filter song.Filter, sort song.Sort, writer songio.Writer, withVerses bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSongs", ctx, filter, sort, writer, withVerses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSongs indicates an expected call of ExportSongs.
+func (mr *MockServiceMockRecorder) ExportSongs(ctx, filter, sort, writer, withVerses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSongs", reflect.TypeOf((*MockService)(nil).ExportSongs), ctx, filter, sort, writer, withVerses)
+}
+
+// GetAlbum mocks base method.
+func (m *MockService) GetAlbum(ctx context.Context, id uint64) (*album.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*album.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockServiceMockRecorder) GetAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockService)(nil).GetAlbum), ctx, id)
+}
+
+// GetAlbumTracks mocks base method.
+func (m *MockService) GetAlbumTracks(ctx context.Context, id uint64) ([]album.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbumTracks", ctx, id)
+	ret0, _ := ret[0].([]album.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbumTracks indicates an expected call of GetAlbumTracks.
+func (mr *MockServiceMockRecorder) GetAlbumTracks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbumTracks", reflect.TypeOf((*MockService)(nil).GetAlbumTracks), ctx, id)
+}
+
+// GetArtist mocks base method.
+func (m *MockService) GetArtist(ctx context.Context, id uint64) (*artist.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*artist.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockServiceMockRecorder) GetArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockService)(nil).GetArtist), ctx, id)
+}
+
+// GetArtistSongs mocks base method.
+func (m *MockService) GetArtistSongs(ctx context.Context, id uint64, page song.Page) (*song.SongPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtistSongs", ctx, id, page)
+	ret0, _ := ret[0].(*song.SongPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtistSongs indicates an expected call of GetArtistSongs.
+func (mr *MockServiceMockRecorder) GetArtistSongs(ctx, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtistSongs", reflect.TypeOf((*MockService)(nil).GetArtistSongs), ctx, id, page)
+}
+
+// GetPaginatedSongs mocks base method.
+func (m *MockService) GetPaginatedSongs(ctx context.Context, filter song.Filter, page song.Page) (*song.SongPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaginatedSongs", ctx, filter, page)
+	ret0, _ := ret[0].(*song.SongPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaginatedSongs indicates an expected call of GetPaginatedSongs.
+func (mr *MockServiceMockRecorder) GetPaginatedSongs(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedSongs", reflect.TypeOf((*MockService)(nil).GetPaginatedSongs), ctx, filter, page)
+}
+
+// GetPaginatedVerses mocks base method.
+func (m *MockService) GetPaginatedVerses(ctx context.Context, s song.Song, offset, limit int) ([]song.Verse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaginatedVerses", ctx, s, offset, limit)
+	ret0, _ := ret[0].([]song.Verse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaginatedVerses indicates an expected call of GetPaginatedVerses.
+func (mr *MockServiceMockRecorder) GetPaginatedVerses(ctx, s, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedVerses", reflect.TypeOf((*MockService)(nil).GetPaginatedVerses), ctx, s, offset, limit)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockService) GetPlaylist(ctx context.Context, id uint64) (*playlist.Details, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*playlist.Details)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockServiceMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockService)(nil).GetPlaylist), ctx, id)
+}
+
+// GetSong mocks base method.
+func (m *MockService) GetSong(ctx context.Context, id uint64) (*song.Details, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSong", ctx, id)
+	ret0, _ := ret[0].(*song.Details)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSong indicates an expected call of GetSong.
+func (mr *MockServiceMockRecorder) GetSong(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockService)(nil).GetSong), ctx, id)
+}
+
+// ImportSongs mocks base method.
+func (m *MockService) ImportSongs(ctx context.Context, reader songio.Reader, enrich bool) (*song.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSongs", ctx, reader, enrich)
+	ret0, _ := ret[0].(*song.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSongs indicates an expected call of ImportSongs.
+func (mr *MockServiceMockRecorder) ImportSongs(ctx, reader, enrich any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSongs", reflect.TypeOf((*MockService)(nil).ImportSongs), ctx, reader, enrich)
+}
+
+// ListAlbums mocks base method.
+func (m *MockService) ListAlbums(ctx context.Context, artistID uint64, title string, offset, limit int) (*album.AlbumPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbums", ctx, artistID, title, offset, limit)
+	ret0, _ := ret[0].(*album.AlbumPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbums indicates an expected call of ListAlbums.
+func (mr *MockServiceMockRecorder) ListAlbums(ctx, artistID, title, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbums", reflect.TypeOf((*MockService)(nil).ListAlbums), ctx, artistID, title, offset, limit)
+}
+
+// ListArtists mocks base method.
+func (m *MockService) ListArtists(ctx context.Context, name string, offset, limit int) (*artist.ArtistPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtists", ctx, name, offset, limit)
+	ret0, _ := ret[0].(*artist.ArtistPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtists indicates an expected call of ListArtists.
+func (mr *MockServiceMockRecorder) ListArtists(ctx, name, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtists", reflect.TypeOf((*MockService)(nil).ListArtists), ctx, name, offset, limit)
+}
+
+// ListPlaylists mocks base method.
+func (m *MockService) ListPlaylists(ctx context.Context, owner string, offset, limit int) (*playlist.PlaylistPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, owner, offset, limit)
+	ret0, _ := ret[0].(*playlist.PlaylistPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockServiceMockRecorder) ListPlaylists(ctx, owner, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockService)(nil).ListPlaylists), ctx, owner, offset, limit)
+}
+
+// MovePlaylistItem mocks base method.
+func (m *MockService) MovePlaylistItem(ctx context.Context, id, itemID uint64, placement playlist.Placement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistItem", ctx, id, itemID, placement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MovePlaylistItem indicates an expected call of MovePlaylistItem.
+func (mr *MockServiceMockRecorder) MovePlaylistItem(ctx, id, itemID, placement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistItem", reflect.TypeOf((*MockService)(nil).MovePlaylistItem), ctx, id, itemID, placement)
+}
+
+// MusicInfoStatus mocks base method.
+func (m *MockService) MusicInfoStatus() (infoservice.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MusicInfoStatus")
+	ret0, _ := ret[0].(infoservice.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MusicInfoStatus indicates an expected call of MusicInfoStatus.
+func (mr *MockServiceMockRecorder) MusicInfoStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MusicInfoStatus", reflect.TypeOf((*MockService)(nil).MusicInfoStatus))
+}
+
+// PatchSong mocks base method.
+func (m *MockService) PatchSong(ctx context.Context, id uint64, patch song.Patch) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSong", ctx, id, patch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchSong indicates an expected call of PatchSong.
+func (mr *MockServiceMockRecorder) PatchSong(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSong", reflect.TypeOf((*MockService)(nil).PatchSong), ctx, id, patch)
+}
+
+// RemoveAlbumTrack mocks base method.
+func (m *MockService) RemoveAlbumTrack(ctx context.Context, albumID, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlbumTrack", ctx, albumID, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlbumTrack indicates an expected call of RemoveAlbumTrack.
+func (mr *MockServiceMockRecorder) RemoveAlbumTrack(ctx, albumID, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlbumTrack", reflect.TypeOf((*MockService)(nil).RemoveAlbumTrack), ctx, albumID, songID)
+}
+
+// RemovePlaylistItem mocks base method.
+func (m *MockService) RemovePlaylistItem(ctx context.Context, id, itemID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistItem", ctx, id, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePlaylistItem indicates an expected call of RemovePlaylistItem.
+func (mr *MockServiceMockRecorder) RemovePlaylistItem(ctx, id, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistItem", reflect.TypeOf((*MockService)(nil).RemovePlaylistItem), ctx, id, itemID)
+}
+
+// SearchSongs mocks base method.
+func (m *MockService) SearchSongs(ctx context.Context, query string, filter song.Filter, offset, limit int) (*[]song.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSongs", ctx, query, filter, offset, limit)
+	ret0, _ := ret[0].(*[]song.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSongs indicates an expected call of SearchSongs.
+func (mr *MockServiceMockRecorder) SearchSongs(ctx, query, filter, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSongs", reflect.TypeOf((*MockService)(nil).SearchSongs), ctx, query, filter, offset, limit)
+}
+
+// SetAlbumTrack mocks base method.
+func (m *MockService) SetAlbumTrack(ctx context.Context, albumID uint64, track album.Track) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTrack", ctx, albumID, track)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTrack indicates an expected call of SetAlbumTrack.
+func (mr *MockServiceMockRecorder) SetAlbumTrack(ctx, albumID, track any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTrack", reflect.TypeOf((*MockService)(nil).SetAlbumTrack), ctx, albumID, track)
+}
+
+// TagCounts mocks base method.
+func (m *MockService) TagCounts(ctx context.Context, filter song.Filter) ([]song.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagCounts", ctx, filter)
+	ret0, _ := ret[0].([]song.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagCounts indicates an expected call of TagCounts.
+func (mr *MockServiceMockRecorder) TagCounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagCounts", reflect.TypeOf((*MockService)(nil).TagCounts), ctx, filter)
+}
+
+// UpdateAlbum mocks base method.
+func (m *MockService) UpdateAlbum(ctx context.Context, a album.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockServiceMockRecorder) UpdateAlbum(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockService)(nil).UpdateAlbum), ctx, a)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockService) UpdateArtist(ctx context.Context, a artist.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockServiceMockRecorder) UpdateArtist(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockService)(nil).UpdateArtist), ctx, a)
+}
+
+// UpdatePlaylist mocks base method.
+func (m *MockService) UpdatePlaylist(ctx context.Context, p playlist.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylist", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylist indicates an expected call of UpdatePlaylist.
+func (mr *MockServiceMockRecorder) UpdatePlaylist(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockService)(nil).UpdatePlaylist), ctx, p)
+}
+
+// UpdateSong mocks base method.
+func (m *MockService) UpdateSong(ctx context.Context, s song.Song) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSong", ctx, s)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSong indicates an expected call of UpdateSong.
+func (mr *MockServiceMockRecorder) UpdateSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSong", reflect.TypeOf((*MockService)(nil).UpdateSong), ctx, s)
+}
+
+// MockSongService is a mock of SongService interface.
+type MockSongService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSongServiceMockRecorder is the mock recorder for MockSongService.
+type MockSongServiceMockRecorder struct {
+	mock *MockSongService
+}
+
+// NewMockSongService creates a new mock instance.
+func NewMockSongService(ctrl *gomock.Controller) *MockSongService {
+	mock := &MockSongService{ctrl: ctrl}
+	mock.recorder = &MockSongServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongService) EXPECT() *MockSongServiceMockRecorder {
+	return m.recorder
+}
+
+// AttachTags mocks base method.
+func (m *MockSongService) AttachTags(ctx context.Context, id uint64, names []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachTags", ctx, id, names)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachTags indicates an expected call of AttachTags.
+func (mr *MockSongServiceMockRecorder) AttachTags(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachTags", reflect.TypeOf((*MockSongService)(nil).AttachTags), ctx, id, names)
+}
+
+// CreateSong mocks base method.
+func (m *MockSongService) CreateSong(ctx context.Context, s song.Song) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSong", ctx, s)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSong indicates an expected call of CreateSong.
+func (mr *MockSongServiceMockRecorder) CreateSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSong", reflect.TypeOf((*MockSongService)(nil).CreateSong), ctx, s)
+}
+
+// DeleteSong mocks base method.
+func (m *MockSongService) DeleteSong(ctx context.Context, s song.Song) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSong", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSong indicates an expected call of DeleteSong.
+func (mr *MockSongServiceMockRecorder) DeleteSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSong", reflect.TypeOf((*MockSongService)(nil).DeleteSong), ctx, s)
+}
+
+// DetachTag mocks base method.
+func (m *MockSongService) DetachTag(ctx context.Context, id uint64, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachTag", ctx, id, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachTag indicates an expected call of DetachTag.
+func (mr *MockSongServiceMockRecorder) DetachTag(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachTag", reflect.TypeOf((*MockSongService)(nil).DetachTag), ctx, id, name)
+}
+
+// ExportSongs mocks base method.
+func (m *MockSongService) ExportSongs(ctx context.Context, filter song.Filter, sort song.Sort, writer songio.Writer, withVerses bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSongs", ctx, filter, sort, writer, withVerses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSongs indicates an expected call of ExportSongs.
+func (mr *MockSongServiceMockRecorder) ExportSongs(ctx, filter, sort, writer, withVerses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSongs", reflect.TypeOf((*MockSongService)(nil).ExportSongs), ctx, filter, sort, writer, withVerses)
+}
+
+// GetPaginatedSongs mocks base method.
+func (m *MockSongService) GetPaginatedSongs(ctx context.Context, filter song.Filter, page song.Page) (*song.SongPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaginatedSongs", ctx, filter, page)
+	ret0, _ := ret[0].(*song.SongPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaginatedSongs indicates an expected call of GetPaginatedSongs.
+func (mr *MockSongServiceMockRecorder) GetPaginatedSongs(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedSongs", reflect.TypeOf((*MockSongService)(nil).GetPaginatedSongs), ctx, filter, page)
+}
+
+// GetPaginatedVerses mocks base method.
+func (m *MockSongService) GetPaginatedVerses(ctx context.Context, s song.Song, offset, limit int) ([]song.Verse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaginatedVerses", ctx, s, offset, limit)
+	ret0, _ := ret[0].([]song.Verse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaginatedVerses indicates an expected call of GetPaginatedVerses.
+func (mr *MockSongServiceMockRecorder) GetPaginatedVerses(ctx, s, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaginatedVerses", reflect.TypeOf((*MockSongService)(nil).GetPaginatedVerses), ctx, s, offset, limit)
+}
+
+// GetSong mocks base method.
+func (m *MockSongService) GetSong(ctx context.Context, id uint64) (*song.Details, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSong", ctx, id)
+	ret0, _ := ret[0].(*song.Details)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSong indicates an expected call of GetSong.
+func (mr *MockSongServiceMockRecorder) GetSong(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockSongService)(nil).GetSong), ctx, id)
+}
+
+// ImportSongs mocks base method.
+func (m *MockSongService) ImportSongs(ctx context.Context, reader songio.Reader, enrich bool) (*song.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSongs", ctx, reader, enrich)
+	ret0, _ := ret[0].(*song.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSongs indicates an expected call of ImportSongs.
+func (mr *MockSongServiceMockRecorder) ImportSongs(ctx, reader, enrich any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSongs", reflect.TypeOf((*MockSongService)(nil).ImportSongs), ctx, reader, enrich)
+}
+
+// MusicInfoStatus mocks base method.
+func (m *MockSongService) MusicInfoStatus() (infoservice.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MusicInfoStatus")
+	ret0, _ := ret[0].(infoservice.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MusicInfoStatus indicates an expected call of MusicInfoStatus.
+func (mr *MockSongServiceMockRecorder) MusicInfoStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MusicInfoStatus", reflect.TypeOf((*MockSongService)(nil).MusicInfoStatus))
+}
+
+// PatchSong mocks base method.
+func (m *MockSongService) PatchSong(ctx context.Context, id uint64, patch song.Patch) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchSong", ctx, id, patch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchSong indicates an expected call of PatchSong.
+func (mr *MockSongServiceMockRecorder) PatchSong(ctx, id, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchSong", reflect.TypeOf((*MockSongService)(nil).PatchSong), ctx, id, patch)
+}
+
+// SearchSongs mocks base method.
+func (m *MockSongService) SearchSongs(ctx context.Context, query string, filter song.Filter, offset, limit int) (*[]song.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSongs", ctx, query, filter, offset, limit)
+	ret0, _ := ret[0].(*[]song.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSongs indicates an expected call of SearchSongs.
+func (mr *MockSongServiceMockRecorder) SearchSongs(ctx, query, filter, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSongs", reflect.TypeOf((*MockSongService)(nil).SearchSongs), ctx, query, filter, offset, limit)
+}
+
+// TagCounts mocks base method.
+func (m *MockSongService) TagCounts(ctx context.Context, filter song.Filter) ([]song.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagCounts", ctx, filter)
+	ret0, _ := ret[0].([]song.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagCounts indicates an expected call of TagCounts.
+func (mr *MockSongServiceMockRecorder) TagCounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagCounts", reflect.TypeOf((*MockSongService)(nil).TagCounts), ctx, filter)
+}
+
+// UpdateSong mocks base method.
+func (m *MockSongService) UpdateSong(ctx context.Context, s song.Song) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSong", ctx, s)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSong indicates an expected call of UpdateSong.
+func (mr *MockSongServiceMockRecorder) UpdateSong(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSong", reflect.TypeOf((*MockSongService)(nil).UpdateSong), ctx, s)
+}
+
+// MockArtistService is a mock of ArtistService interface.
+type MockArtistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtistServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockArtistServiceMockRecorder is the mock recorder for MockArtistService.
+type MockArtistServiceMockRecorder struct {
+	mock *MockArtistService
+}
+
+// NewMockArtistService creates a new mock instance.
+func NewMockArtistService(ctrl *gomock.Controller) *MockArtistService {
+	mock := &MockArtistService{ctrl: ctrl}
+	mock.recorder = &MockArtistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtistService) EXPECT() *MockArtistServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateArtist mocks base method.
+func (m *MockArtistService) CreateArtist(ctx context.Context, a artist.Artist) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, a)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockArtistServiceMockRecorder) CreateArtist(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockArtistService)(nil).CreateArtist), ctx, a)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockArtistService) DeleteArtist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockArtistServiceMockRecorder) DeleteArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockArtistService)(nil).DeleteArtist), ctx, id)
+}
+
+// GetArtist mocks base method.
+func (m *MockArtistService) GetArtist(ctx context.Context, id uint64) (*artist.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*artist.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockArtistServiceMockRecorder) GetArtist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockArtistService)(nil).GetArtist), ctx, id)
+}
+
+// GetArtistSongs mocks base method.
+func (m *MockArtistService) GetArtistSongs(ctx context.Context, id uint64, page song.Page) (*song.SongPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtistSongs", ctx, id, page)
+	ret0, _ := ret[0].(*song.SongPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtistSongs indicates an expected call of GetArtistSongs.
+func (mr *MockArtistServiceMockRecorder) GetArtistSongs(ctx, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtistSongs", reflect.TypeOf((*MockArtistService)(nil).GetArtistSongs), ctx, id, page)
+}
+
+// ListArtists mocks base method.
+func (m *MockArtistService) ListArtists(ctx context.Context, name string, offset, limit int) (*artist.ArtistPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArtists", ctx, name, offset, limit)
+	ret0, _ := ret[0].(*artist.ArtistPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArtists indicates an expected call of ListArtists.
+func (mr *MockArtistServiceMockRecorder) ListArtists(ctx, name, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtists", reflect.TypeOf((*MockArtistService)(nil).ListArtists), ctx, name, offset, limit)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockArtistService) UpdateArtist(ctx context.Context, a artist.Artist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockArtistServiceMockRecorder) UpdateArtist(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockArtistService)(nil).UpdateArtist), ctx, a)
+}
+
+// MockAlbumService is a mock of AlbumService interface.
+type MockAlbumService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlbumServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAlbumServiceMockRecorder is the mock recorder for MockAlbumService.
+type MockAlbumServiceMockRecorder struct {
+	mock *MockAlbumService
+}
+
+// NewMockAlbumService creates a new mock instance.
+func NewMockAlbumService(ctrl *gomock.Controller) *MockAlbumService {
+	mock := &MockAlbumService{ctrl: ctrl}
+	mock.recorder = &MockAlbumServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlbumService) EXPECT() *MockAlbumServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAlbum mocks base method.
+func (m *MockAlbumService) CreateAlbum(ctx context.Context, a album.Album) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, a)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockAlbumServiceMockRecorder) CreateAlbum(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockAlbumService)(nil).CreateAlbum), ctx, a)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockAlbumService) DeleteAlbum(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockAlbumServiceMockRecorder) DeleteAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockAlbumService)(nil).DeleteAlbum), ctx, id)
+}
+
+// GetAlbum mocks base method.
+func (m *MockAlbumService) GetAlbum(ctx context.Context, id uint64) (*album.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*album.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockAlbumServiceMockRecorder) GetAlbum(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockAlbumService)(nil).GetAlbum), ctx, id)
+}
+
+// GetAlbumTracks mocks base method.
+func (m *MockAlbumService) GetAlbumTracks(ctx context.Context, id uint64) ([]album.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbumTracks", ctx, id)
+	ret0, _ := ret[0].([]album.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbumTracks indicates an expected call of GetAlbumTracks.
+func (mr *MockAlbumServiceMockRecorder) GetAlbumTracks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbumTracks", reflect.TypeOf((*MockAlbumService)(nil).GetAlbumTracks), ctx, id)
+}
+
+// ListAlbums mocks base method.
+func (m *MockAlbumService) ListAlbums(ctx context.Context, artistID uint64, title string, offset, limit int) (*album.AlbumPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlbums", ctx, artistID, title, offset, limit)
+	ret0, _ := ret[0].(*album.AlbumPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlbums indicates an expected call of ListAlbums.
+func (mr *MockAlbumServiceMockRecorder) ListAlbums(ctx, artistID, title, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlbums", reflect.TypeOf((*MockAlbumService)(nil).ListAlbums), ctx, artistID, title, offset, limit)
+}
+
+// RemoveAlbumTrack mocks base method.
+func (m *MockAlbumService) RemoveAlbumTrack(ctx context.Context, albumID, songID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlbumTrack", ctx, albumID, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlbumTrack indicates an expected call of RemoveAlbumTrack.
+func (mr *MockAlbumServiceMockRecorder) RemoveAlbumTrack(ctx, albumID, songID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlbumTrack", reflect.TypeOf((*MockAlbumService)(nil).RemoveAlbumTrack), ctx, albumID, songID)
+}
+
+// SetAlbumTrack mocks base method.
+func (m *MockAlbumService) SetAlbumTrack(ctx context.Context, albumID uint64, track album.Track) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTrack", ctx, albumID, track)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTrack indicates an expected call of SetAlbumTrack.
+func (mr *MockAlbumServiceMockRecorder) SetAlbumTrack(ctx, albumID, track any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTrack", reflect.TypeOf((*MockAlbumService)(nil).SetAlbumTrack), ctx, albumID, track)
+}
+
+// UpdateAlbum mocks base method.
+func (m *MockAlbumService) UpdateAlbum(ctx context.Context, a album.Album) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockAlbumServiceMockRecorder) UpdateAlbum(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockAlbumService)(nil).UpdateAlbum), ctx, a)
+}
+
+// MockPlaylistService is a mock of PlaylistService interface.
+type MockPlaylistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaylistServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPlaylistServiceMockRecorder is the mock recorder for MockPlaylistService.
+type MockPlaylistServiceMockRecorder struct {
+	mock *MockPlaylistService
+}
+
+// NewMockPlaylistService creates a new mock instance.
+func NewMockPlaylistService(ctrl *gomock.Controller) *MockPlaylistService {
+	mock := &MockPlaylistService{ctrl: ctrl}
+	mock.recorder = &MockPlaylistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaylistService) EXPECT() *MockPlaylistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPlaylistItem mocks base method.
+func (m *MockPlaylistService) AddPlaylistItem(ctx context.Context, id, songID uint64, placement playlist.Placement) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistItem", ctx, id, songID, placement)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistItem indicates an expected call of AddPlaylistItem.
+func (mr *MockPlaylistServiceMockRecorder) AddPlaylistItem(ctx, id, songID, placement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistItem", reflect.TypeOf((*MockPlaylistService)(nil).AddPlaylistItem), ctx, id, songID, placement)
+}
+
+// CreatePlaylist mocks base method.
+func (m *MockPlaylistService) CreatePlaylist(ctx context.Context, p playlist.Playlist) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, p)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockPlaylistServiceMockRecorder) CreatePlaylist(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*MockPlaylistService)(nil).CreatePlaylist), ctx, p)
+}
+
+// DeletePlaylist mocks base method.
+func (m *MockPlaylistService) DeletePlaylist(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockPlaylistServiceMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*MockPlaylistService)(nil).DeletePlaylist), ctx, id)
+}
+
+// GetPlaylist mocks base method.
+func (m *MockPlaylistService) GetPlaylist(ctx context.Context, id uint64) (*playlist.Details, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*playlist.Details)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockPlaylistServiceMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockPlaylistService)(nil).GetPlaylist), ctx, id)
+}
+
+// ListPlaylists mocks base method.
+func (m *MockPlaylistService) ListPlaylists(ctx context.Context, owner string, offset, limit int) (*playlist.PlaylistPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, owner, offset, limit)
+	ret0, _ := ret[0].(*playlist.PlaylistPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockPlaylistServiceMockRecorder) ListPlaylists(ctx, owner, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockPlaylistService)(nil).ListPlaylists), ctx, owner, offset, limit)
+}
+
+// MovePlaylistItem mocks base method.
+func (m *MockPlaylistService) MovePlaylistItem(ctx context.Context, id, itemID uint64, placement playlist.Placement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistItem", ctx, id, itemID, placement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MovePlaylistItem indicates an expected call of MovePlaylistItem.
+func (mr *MockPlaylistServiceMockRecorder) MovePlaylistItem(ctx, id, itemID, placement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistItem", reflect.TypeOf((*MockPlaylistService)(nil).MovePlaylistItem), ctx, id, itemID, placement)
+}
+
+// RemovePlaylistItem mocks base method.
+func (m *MockPlaylistService) RemovePlaylistItem(ctx context.Context, id, itemID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistItem", ctx, id, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePlaylistItem indicates an expected call of RemovePlaylistItem.
+func (mr *MockPlaylistServiceMockRecorder) RemovePlaylistItem(ctx, id, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistItem", reflect.TypeOf((*MockPlaylistService)(nil).RemovePlaylistItem), ctx, id, itemID)
+}
+
+// UpdatePlaylist mocks base method.
+func (m *MockPlaylistService) UpdatePlaylist(ctx context.Context, p playlist.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaylist", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaylist indicates an expected call of UpdatePlaylist.
+func (mr *MockPlaylistServiceMockRecorder) UpdatePlaylist(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockPlaylistService)(nil).UpdatePlaylist), ctx, p)
+}
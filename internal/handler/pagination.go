@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePagination reads the 1-based offset and the page size, falling back
+// to the defaults for missing or out of range values.
+func parsePagination(ctx *gin.Context) (offset, limit int) {
+	offset, _ = strconv.Atoi(ctx.DefaultQuery("offset", "1"))
+	if offset < 1 {
+		offset = 1
+	}
+
+	limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return offset, limit
+}
+
+// parsePage reads the pagination, sort and cursor of a song listing.
+func parsePage(ctx *gin.Context) (msong.Page, error) {
+	offset, limit := parsePagination(ctx)
+
+	sort, err := msong.ParseSort(ctx.Query("sort"))
+	if err != nil {
+		return msong.Page{}, err
+	}
+
+	page := msong.Page{
+		Offset: offset,
+		Limit:  limit,
+		Sort:   sort,
+	}
+
+	if value, ok := ctx.GetQuery("cursor"); ok {
+		cursor, err := msong.DecodeCursor(value)
+		if err != nil {
+			return msong.Page{}, err
+		}
+
+		page.Cursor = cursor
+	}
+
+	return page, nil
+}
+
+// writeSongPage responds with a page of a song listing and its Link header.
+func writeSongPage(ctx *gin.Context, page msong.Page, result *msong.SongPage) {
+	response := songsResponse{
+		Songs:   result.Songs,
+		Total:   result.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+		HasNext: result.HasNext,
+	}
+
+	if result.Next != nil {
+		next := result.Next.Encode()
+		response.NextCursor = &next
+	}
+
+	setLinkHeader(ctx, page, result)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// setLinkHeader sets an RFC 8288 Link header with first, prev, next and last
+// pages. In cursor mode only first and next can be addressed.
+func setLinkHeader(ctx *gin.Context, page msong.Page, result *msong.SongPage) {
+	links := []string{
+		pageLink(ctx, "first", map[string]string{"offset": "1"}),
+	}
+
+	if page.Cursor == nil && page.Offset > 1 {
+		prev := max(page.Offset-page.Limit, 1)
+		links = append(links, pageLink(ctx, "prev", map[string]string{"offset": strconv.Itoa(prev)}))
+	}
+
+	if result.HasNext {
+		if page.Cursor != nil {
+			links = append(links, pageLink(ctx, "next", map[string]string{"cursor": result.Next.Encode()}))
+		} else {
+			next := page.Offset + page.Limit
+			links = append(links, pageLink(ctx, "next", map[string]string{"offset": strconv.Itoa(next)}))
+		}
+	}
+
+	if page.Cursor == nil && result.Total > 0 {
+		last := (result.Total-1)/page.Limit*page.Limit + 1
+		links = append(links, pageLink(ctx, "last", map[string]string{"offset": strconv.Itoa(last)}))
+	}
+
+	ctx.Header("Link", strings.Join(links, ", "))
+}
+
+func pageLink(ctx *gin.Context, rel string, params map[string]string) string {
+	query := ctx.Request.URL.Query()
+	query.Del("offset")
+	query.Del("cursor")
+
+	for k, v := range params {
+		query.Set(k, v)
+	}
+
+	link := url.URL{
+		Path:     ctx.Request.URL.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return fmt.Sprintf("<%s>; rel=%q", link.String(), rel)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// patchRules holds the validation tags of the fields a merge patch may set.
+var patchRules = map[string]string{
+	"group":       "required,max=255",
+	"song":        "required,max=255",
+	"releaseDate": "required,releasedate",
+	"link":        "required,httplink",
+	"text":        "",
+}
+
+// parseMergePatch reads an RFC 7396 merge patch of a song. Absent members are
+// left unchanged, null clears the text and is rejected for required fields.
+func parseMergePatch(body []byte) (msong.Patch, error) {
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &members); err != nil {
+		return msong.Patch{}, errInvalidBody
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	validate, _ := binding.Validator.Engine().(*validator.Validate)
+
+	patch := msong.Patch{}
+	fields := []apperror.FieldError{}
+
+	for _, name := range names {
+		rules, ok := patchRules[name]
+		if !ok {
+			fields = append(fields, apperror.FieldError{Field: name, Message: "is not a song field"})
+			continue
+		}
+
+		var value *string
+		if err := json.Unmarshal(members[name], &value); err != nil {
+			fields = append(fields, apperror.FieldError{Field: name, Message: "must be a string"})
+			continue
+		}
+
+		if value == nil {
+			value = new(string)
+		}
+
+		if rules != "" && validate != nil {
+			if err := validate.Var(*value, rules); err != nil {
+				var validationErrs validator.ValidationErrors
+				if errors.As(err, &validationErrs) {
+					fields = append(fields, apperror.FieldError{
+						Field:   name,
+						Message: fieldErrorMessage(validationErrs[0]),
+					})
+				}
+
+				continue
+			}
+		}
+
+		switch name {
+		case "group":
+			patch.Group = value
+		case "song":
+			patch.Song = value
+		case "releaseDate":
+			patch.ReleaseDate = value
+		case "link":
+			patch.Link = value
+		case "text":
+			verses := msong.SplitIntoVerses(*value)
+			patch.Verses = &verses
+		}
+	}
+
+	if len(fields) > 0 {
+		return msong.Patch{}, &apperror.ValidationError{Fields: fields}
+	}
+
+	return patch, nil
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	mplaylist "online-song-library/internal/model/playlist"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// playlistRequest is the body of CreatePlaylist and UpdatePlaylist.
+type playlistRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Owner       string `json:"owner" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+// placementRequest says where an item goes: before or after another item,
+// at the end if neither is set.
+type placementRequest struct {
+	Before uint64 `json:"before"`
+	After  uint64 `json:"after"`
+}
+
+// ListPlaylists godoc
+// @Summary      Get paginated list of playlists
+// @Description  Retrieve playlists, the most recently changed first.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        owner   query   string  false  "Owner of the playlists"
+// @Param        offset  query   int     false  "Page offset (default 1)"
+// @Param        limit   query   int     false  "Number of items per page (default 10, max 100)"
+// @Success      200 {object} handler.playlistsResponse
+// @Failure      500 {object} handler.problem "failed to fetch playlists"
+// @Router       /playlists/ [get]
+func (handler *Handler) ListPlaylists(ctx *gin.Context) {
+	logrus.Debug("ListPlaylists: received request")
+
+	owner := ctx.Query("owner")
+	offset, limit := parsePagination(ctx)
+
+	logrus.Debugf("ListPlaylists: owner=%q, offset=%d, limit=%d", owner, offset, limit)
+
+	result, err := handler.service.ListPlaylists(ctx, owner, offset, limit)
+	if err != nil {
+		logrus.Errorf("ListPlaylists: failed to fetch playlists: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("ListPlaylists: retrieved %d of %d playlists", len(result.Playlists), result.Total)
+
+	ctx.JSON(http.StatusOK, playlistsResponse{
+		Playlists: result.Playlists,
+		Total:     result.Total,
+		Offset:    offset,
+		Limit:     limit,
+	})
+}
+
+// GetPlaylist godoc
+// @Summary      Get a playlist
+// @Description  Retrieve a playlist by ID with its items in order and the metadata of their songs.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id  path  uint64  true  "Playlist ID"
+// @Success      200 {object} playlist.Details
+// @Failure      400 {object} handler.problem "invalid playlist ID"
+// @Failure      404 {object} handler.problem "playlist not found"
+// @Failure      500 {object} handler.problem "failed to fetch playlist"
+// @Router       /playlists/{id} [get]
+func (handler *Handler) GetPlaylist(ctx *gin.Context) {
+	logrus.Debug("GetPlaylist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("GetPlaylist: invalid playlist ID")
+		ctx.Error(errInvalidPlaylistID)
+		return
+	}
+
+	details, err := handler.service.GetPlaylist(ctx, id)
+	if err != nil {
+		logrus.Errorf("GetPlaylist: failed to fetch playlist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetPlaylist: successfully fetched playlist ID=%d with %d items", id, len(details.Items))
+
+	ctx.JSON(http.StatusOK, details)
+}
+
+// CreatePlaylist godoc
+// @Summary      Create a new playlist
+// @Description  Add an empty playlist.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.playlistRequest true "playlist fields"
+// @Success      201 {object} handler.createdResponse "Created"
+// @Header       201 {string} Location "URL of the new playlist"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      422 {object} handler.problem "invalid playlist fields"
+// @Failure      500 {object} handler.problem "failed to create playlist"
+// @Router       /playlists/ [post]
+func (handler *Handler) CreatePlaylist(ctx *gin.Context) {
+	logrus.Debug("CreatePlaylist: received request")
+
+	var req playlistRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("CreatePlaylist: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	id, err := handler.service.CreatePlaylist(ctx, mplaylist.Playlist{
+		Name:        req.Name,
+		Owner:       req.Owner,
+		Description: req.Description,
+	})
+	if err != nil {
+		logrus.Errorf("CreatePlaylist: failed to create playlist, error=%v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("CreatePlaylist: successfully created playlist ID=%d", id)
+	ctx.Header("Location", fmt.Sprintf("/playlists/%d", id))
+	ctx.JSON(http.StatusCreated, createdResponse{ID: id})
+}
+
+// UpdatePlaylist godoc
+// @Summary      Update an existing playlist
+// @Description  Replace the name, owner and description of a playlist by ID, its items are kept.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Playlist ID"
+// @Param        request body handler.playlistRequest true "playlist fields"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid request body"
+// @Failure      404 {object} handler.problem "playlist not found"
+// @Failure      422 {object} handler.problem "invalid playlist fields"
+// @Failure      500 {object} handler.problem "failed to update playlist"
+// @Router       /playlists/{id} [put]
+func (handler *Handler) UpdatePlaylist(ctx *gin.Context) {
+	logrus.Debug("UpdatePlaylist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("UpdatePlaylist: invalid playlist ID")
+		ctx.Error(errInvalidPlaylistID)
+		return
+	}
+
+	var req playlistRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("UpdatePlaylist: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	if err := handler.service.UpdatePlaylist(ctx, mplaylist.Playlist{
+		ID:          id,
+		Name:        req.Name,
+		Owner:       req.Owner,
+		Description: req.Description,
+	}); err != nil {
+		logrus.Errorf("UpdatePlaylist: failed to update playlist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("UpdatePlaylist: successfully updated playlist ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// DeletePlaylist godoc
+// @Summary      Delete a playlist
+// @Description  Remove a playlist and its items by ID, the songs are kept.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id   path   uint64  true   "Playlist ID"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid playlist ID"
+// @Failure      404 {object} handler.problem "playlist not found"
+// @Failure      500 {object} handler.problem "failed to delete playlist"
+// @Router       /playlists/{id} [delete]
+func (handler *Handler) DeletePlaylist(ctx *gin.Context) {
+	logrus.Debug("DeletePlaylist: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("DeletePlaylist: invalid playlist ID")
+		ctx.Error(errInvalidPlaylistID)
+		return
+	}
+
+	if err := handler.service.DeletePlaylist(ctx, id); err != nil {
+		logrus.Errorf("DeletePlaylist: failed to delete playlist ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("DeletePlaylist: successfully deleted playlist ID=%d", id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// AddPlaylistItem godoc
+// @Summary      Add a song to a playlist
+// @Description  Put a song on a playlist before or after one of its items, at the end if neither is given. A song can be added more than once.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Playlist ID"
+// @Param        request body handler.AddPlaylistItem.Request true "song and where to put it"
+// @Success      201 {object} handler.createdResponse "ID of the new item"
+// @Failure      400 {object} handler.problem "invalid playlist ID or request body"
+// @Failure      404 {object} handler.problem "playlist, song or neighbour item not found"
+// @Failure      422 {object} handler.problem "both before and after are set"
+// @Failure      500 {object} handler.problem "failed to add song"
+// @Router       /playlists/{id}/items [post]
+func (handler *Handler) AddPlaylistItem(ctx *gin.Context) {
+	logrus.Debug("AddPlaylistItem: received request")
+
+	type Request struct {
+		SongID uint64 `json:"songId" binding:"required"`
+		placementRequest
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("AddPlaylistItem: invalid playlist ID")
+		ctx.Error(errInvalidPlaylistID)
+		return
+	}
+
+	var req Request
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("AddPlaylistItem: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	itemID, err := handler.service.AddPlaylistItem(ctx, id, req.SongID, mplaylist.Placement{
+		Before: req.Before,
+		After:  req.After,
+	})
+	if err != nil {
+		logrus.Errorf("AddPlaylistItem: failed to add song ID=%d to playlist ID=%d: %v", req.SongID, id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("AddPlaylistItem: added song ID=%d to playlist ID=%d as item ID=%d", req.SongID, id, itemID)
+
+	ctx.JSON(http.StatusCreated, createdResponse{ID: itemID})
+}
+
+// MovePlaylistItem godoc
+// @Summary      Move a playlist item
+// @Description  Move an item right before or after another item of the playlist, to the end if neither is given. The other items keep their places.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Playlist ID"
+// @Param        itemId  path uint64 true "Item ID"
+// @Param        request body handler.placementRequest true "where to put the item"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid playlist or item ID"
+// @Failure      404 {object} handler.problem "playlist or item not found"
+// @Failure      422 {object} handler.problem "both before and after are set"
+// @Failure      500 {object} handler.problem "failed to move item"
+// @Router       /playlists/{id}/items/{itemId}/move [post]
+func (handler *Handler) MovePlaylistItem(ctx *gin.Context) {
+	logrus.Debug("MovePlaylistItem: received request")
+
+	id, itemID, ok := parseItemIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var req placementRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("MovePlaylistItem: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	if err := handler.service.MovePlaylistItem(ctx, id, itemID, mplaylist.Placement{
+		Before: req.Before,
+		After:  req.After,
+	}); err != nil {
+		logrus.Errorf("MovePlaylistItem: failed to move item ID=%d of playlist ID=%d: %v", itemID, id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("MovePlaylistItem: moved item ID=%d of playlist ID=%d", itemID, id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// RemovePlaylistItem godoc
+// @Summary      Remove a playlist item
+// @Description  Take an item off a playlist, the song is kept.
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Playlist ID"
+// @Param        itemId  path uint64 true "Item ID"
+// @Success      204 {string} string "No content"
+// @Failure      400 {object} handler.problem "invalid playlist or item ID"
+// @Failure      404 {object} handler.problem "playlist or item not found"
+// @Failure      500 {object} handler.problem "failed to remove item"
+// @Router       /playlists/{id}/items/{itemId} [delete]
+func (handler *Handler) RemovePlaylistItem(ctx *gin.Context) {
+	logrus.Debug("RemovePlaylistItem: received request")
+
+	id, itemID, ok := parseItemIDs(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.service.RemovePlaylistItem(ctx, id, itemID); err != nil {
+		logrus.Errorf("RemovePlaylistItem: failed to remove item ID=%d of playlist ID=%d: %v", itemID, id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("RemovePlaylistItem: removed item ID=%d of playlist ID=%d", itemID, id)
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// parseItemIDs reads the playlist and item IDs of an item route. On failure
+// the error is attached to ctx.
+func parseItemIDs(ctx *gin.Context) (id, itemID uint64, ok bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("parseItemIDs: invalid playlist ID")
+		ctx.Error(errInvalidPlaylistID)
+		return 0, 0, false
+	}
+
+	itemID, err = strconv.ParseUint(ctx.Param("itemId"), 10, 64)
+	if err != nil {
+		logrus.Error("parseItemIDs: invalid item ID")
+		ctx.Error(errInvalidItemID)
+		return 0, 0, false
+	}
+
+	return id, itemID, true
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"online-song-library/internal/model/album"
+	"online-song-library/internal/model/artist"
+	"online-song-library/internal/model/playlist"
+	"online-song-library/internal/model/song"
+)
+
+type songsResponse struct {
+	Songs      []song.Song `json:"songs"`
+	Total      int         `json:"total"`
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+	HasNext    bool        `json:"hasNext"`
+	NextCursor *string     `json:"nextCursor"`
+}
+
+type versesResponse struct {
+	Verses []song.Verse `json:"verses"`
+	Offset int          `json:"offset"`
+	Limit  int          `json:"limit"`
+}
+
+type createdResponse struct {
+	ID uint64 `json:"id"`
+}
+
+type artistsResponse struct {
+	Artists []artist.Artist `json:"artists"`
+	Total   int             `json:"total"`
+	Offset  int             `json:"offset"`
+	Limit   int             `json:"limit"`
+}
+
+type albumsResponse struct {
+	Albums []album.Album `json:"albums"`
+	Total  int           `json:"total"`
+	Offset int           `json:"offset"`
+	Limit  int           `json:"limit"`
+}
+
+type tracksResponse struct {
+	Tracks []album.Track `json:"tracks"`
+}
+
+type tagCountsResponse struct {
+	Tags []song.TagCount `json:"tags"`
+}
+
+type playlistsResponse struct {
+	Playlists []playlist.Playlist `json:"playlists"`
+	Total     int                 `json:"total"`
+	Offset    int                 `json:"offset"`
+	Limit     int                 `json:"limit"`
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+
+	"online-song-library/internal/clients/infoservice"
+	malbum "online-song-library/internal/model/album"
+	martist "online-song-library/internal/model/artist"
+	mplaylist "online-song-library/internal/model/playlist"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/songio"
+)
+
+//go:generate mockgen -source=service.go -destination=mocks/service.go -package=mocks
+
+// Service is the business logic behind the handlers.
+// *service.Service implements it.
+type Service interface {
+	SongService
+	ArtistService
+	AlbumService
+	PlaylistService
+}
+
+// SongService is the song part of Service.
+type SongService interface {
+	GetPaginatedSongs(ctx context.Context, filter msong.Filter, page msong.Page) (*msong.SongPage, error)
+	SearchSongs(
+		ctx context.Context,
+		query string,
+		filter msong.Filter,
+		offset, limit int,
+	) (*[]msong.SearchResult, error)
+	GetSong(ctx context.Context, id uint64) (*msong.Details, error)
+	GetPaginatedVerses(ctx context.Context, s msong.Song, offset, limit int) ([]msong.Verse, error)
+	CreateSong(ctx context.Context, s msong.Song) (uint64, error)
+	UpdateSong(ctx context.Context, s msong.Song) (int, error)
+	PatchSong(ctx context.Context, id uint64, patch msong.Patch) (int, error)
+	DeleteSong(ctx context.Context, s msong.Song) error
+	AttachTags(ctx context.Context, id uint64, names []string) (int, error)
+	DetachTag(ctx context.Context, id uint64, name string) (int, error)
+	TagCounts(ctx context.Context, filter msong.Filter) ([]msong.TagCount, error)
+	ImportSongs(ctx context.Context, reader songio.Reader, enrich bool) (*msong.ImportReport, error)
+	ExportSongs(ctx context.Context, filter msong.Filter, sort msong.Sort, writer songio.Writer, withVerses bool) error
+	MusicInfoStatus() (infoservice.Status, error)
+}
+
+// ArtistService is the artist part of Service.
+type ArtistService interface {
+	ListArtists(ctx context.Context, name string, offset, limit int) (*martist.ArtistPage, error)
+	GetArtist(ctx context.Context, id uint64) (*martist.Artist, error)
+	GetArtistSongs(ctx context.Context, id uint64, page msong.Page) (*msong.SongPage, error)
+	CreateArtist(ctx context.Context, a martist.Artist) (uint64, error)
+	UpdateArtist(ctx context.Context, a martist.Artist) error
+	DeleteArtist(ctx context.Context, id uint64) error
+}
+
+// AlbumService is the album part of Service.
+type AlbumService interface {
+	ListAlbums(ctx context.Context, artistID uint64, title string, offset, limit int) (*malbum.AlbumPage, error)
+	GetAlbum(ctx context.Context, id uint64) (*malbum.Album, error)
+	CreateAlbum(ctx context.Context, a malbum.Album) (uint64, error)
+	UpdateAlbum(ctx context.Context, a malbum.Album) error
+	DeleteAlbum(ctx context.Context, id uint64) error
+	GetAlbumTracks(ctx context.Context, id uint64) ([]malbum.Track, error)
+	SetAlbumTrack(ctx context.Context, albumID uint64, track malbum.Track) error
+	RemoveAlbumTrack(ctx context.Context, albumID, songID uint64) error
+}
+
+// PlaylistService is the playlist part of Service.
+type PlaylistService interface {
+	ListPlaylists(ctx context.Context, owner string, offset, limit int) (*mplaylist.PlaylistPage, error)
+	GetPlaylist(ctx context.Context, id uint64) (*mplaylist.Details, error)
+	CreatePlaylist(ctx context.Context, p mplaylist.Playlist) (uint64, error)
+	UpdatePlaylist(ctx context.Context, p mplaylist.Playlist) error
+	DeletePlaylist(ctx context.Context, id uint64) error
+	AddPlaylistItem(ctx context.Context, id, songID uint64, placement mplaylist.Placement) (uint64, error)
+	MovePlaylistItem(ctx context.Context, id, itemID uint64, placement mplaylist.Placement) error
+	RemovePlaylistItem(ctx context.Context, id, itemID uint64) error
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMusicInfoStatus godoc
+// @Summary      Music info service status
+// @Description  Circuit breaker state and concurrency of calls to the external music info service.
+// @Tags         status
+// @Produce      json
+// @Success      200 {object} infoservice.Status
+// @Failure      404 {object} handler.problem "music info service is not configured"
+// @Router       /status/music-info [get]
+func (handler *Handler) GetMusicInfoStatus(ctx *gin.Context) {
+	status, err := handler.service.MusicInfoStatus()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
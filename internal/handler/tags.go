@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// GetTagCounts godoc
+// @Summary      Count songs per tag
+// @Description  Count the songs matching the filters per tag, for faceted navigation. Takes the same filters as the song listing.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        group           query   string  false  "Group name (exact match, group[contains|prefix|ilike] for patterns)"
+// @Param        song            query   string  false  "Song name (exact match, song[contains|prefix|ilike] for patterns)"
+// @Param        releaseDate     query   string  false  "Release date, DD.MM.YYYY or YYYY-MM-DD"
+// @Param        releaseDateFrom query   string  false  "Released on or after this date"
+// @Param        releaseDateTo   query   string  false  "Released on or before this date"
+// @Param        link            query   string  false  "Link (exact match, link[contains|prefix|ilike] for patterns)"
+// @Param        tags            query   string  false  "Comma separated tags, songs with any of them (tags[all] for all of them)"
+// @Success      200 {object} handler.tagCountsResponse
+// @Failure      400 {object} handler.problem "invalid filter"
+// @Failure      500 {object} handler.problem "failed to count tags"
+// @Router       /songs/tags [get]
+func (handler *Handler) GetTagCounts(ctx *gin.Context) {
+	logrus.Debug("GetTagCounts: received request")
+
+	filter := parseSongFilter(ctx)
+
+	logrus.Debugf("GetTagCounts: filter=%v", filter)
+
+	counts, err := handler.service.TagCounts(ctx, filter)
+	if err != nil {
+		logrus.Errorf("GetTagCounts: failed to count tags: %v", err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("GetTagCounts: counted %d tags", len(counts))
+
+	ctx.JSON(http.StatusOK, tagCountsResponse{Tags: counts})
+}
+
+// AttachTags godoc
+// @Summary      Tag a song
+// @Description  Add tags to a song. Tags are matched case-insensitively, unknown ones are created.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id      path uint64 true "Song ID"
+// @Param        request body handler.AttachTags.Request true "tags to add"
+// @Success      204 {string} string "No content"
+// @Header       204 {string} ETag "song version"
+// @Failure      400 {object} handler.problem "invalid song ID or request body"
+// @Failure      404 {object} handler.problem "song not found"
+// @Failure      422 {object} handler.problem "invalid tags"
+// @Failure      500 {object} handler.problem "failed to tag song"
+// @Router       /songs/{id}/tags [post]
+func (handler *Handler) AttachTags(ctx *gin.Context) {
+	logrus.Debug("AttachTags: received request")
+
+	type Request struct {
+		Tags []string `json:"tags" binding:"required,min=1,dive,tagname"`
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("AttachTags: invalid song ID")
+		ctx.Error(errInvalidSongID)
+		return
+	}
+
+	var req Request
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logrus.Errorf("AttachTags: invalid request body: %v", err)
+		ctx.Error(bindError(err))
+		return
+	}
+
+	version, err := handler.service.AttachTags(ctx, id, req.Tags)
+	if err != nil {
+		logrus.Errorf("AttachTags: failed to tag song ID=%d: %v", id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("AttachTags: tagged song ID=%d with %v", id, req.Tags)
+
+	ctx.Header("ETag", etag(version))
+
+	ctx.JSON(http.StatusNoContent, "")
+}
+
+// DetachTag godoc
+// @Summary      Untag a song
+// @Description  Remove a tag from a song, the tag is kept for other songs.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path uint64 true "Song ID"
+// @Param        tag  path string true "Tag name"
+// @Success      204 {string} string "No content"
+// @Header       204 {string} ETag "new song version"
+// @Failure      400 {object} handler.problem "invalid song ID"
+// @Failure      404 {object} handler.problem "song not found or it has no such tag"
+// @Failure      500 {object} handler.problem "failed to untag song"
+// @Router       /songs/{id}/tags/{tag} [delete]
+func (handler *Handler) DetachTag(ctx *gin.Context) {
+	logrus.Debug("DetachTag: received request")
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		logrus.Error("DetachTag: invalid song ID")
+		ctx.Error(errInvalidSongID)
+		return
+	}
+
+	tag := ctx.Param("tag")
+
+	version, err := handler.service.DetachTag(ctx, id, tag)
+	if err != nil {
+		logrus.Errorf("DetachTag: failed to remove tag %q from song ID=%d: %v", tag, id, err)
+		ctx.Error(err)
+		return
+	}
+
+	logrus.Infof("DetachTag: removed tag %q from song ID=%d", tag, id)
+
+	ctx.Header("ETag", etag(version))
+
+	ctx.JSON(http.StatusNoContent, "")
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// registerValidators adds the custom tags used by request structs to the
+// validator gin binds requests with and makes it report json field names.
+func registerValidators() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	_ = validate.RegisterValidation("releasedate", validateReleaseDate)
+	_ = validate.RegisterValidation("httplink", validateHTTPLink)
+	_ = validate.RegisterValidation("tagname", validateTagName)
+}
+
+func validateReleaseDate(fl validator.FieldLevel) bool {
+	_, err := msong.ParseReleaseDate(fl.Field().String())
+
+	return err == nil
+}
+
+func validateHTTPLink(fl validator.FieldLevel) bool {
+	return msong.IsHTTPLink(fl.Field().String())
+}
+
+func validateTagName(fl validator.FieldLevel) bool {
+	return msong.IsTagName(fl.Field().String())
+}
+
+// bindError converts an error from ShouldBindJSON into a domain error: field
+// errors for failed validation, a bad request for malformed bodies.
+func bindError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return errInvalidBody
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, apperror.FieldError{
+			Field:   fieldErr.Field(),
+			Message: fieldErrorMessage(fieldErr),
+		})
+	}
+
+	return &apperror.ValidationError{Fields: fields}
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return fmt.Sprintf("must be at most %s characters long", fieldErr.Param())
+	case "releasedate":
+		return "must be a date in DD.MM.YYYY or YYYY-MM-DD format"
+	case "httplink":
+		return "must be an http or https URL"
+	case "tagname":
+		return fmt.Sprintf("must be 1 to %d characters long without commas", msong.MaxTagLength)
+	default:
+		return "is invalid"
+	}
+}
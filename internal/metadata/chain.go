@@ -0,0 +1,87 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+
+	"online-song-library/internal/clients/infoservice"
+	msong "online-song-library/internal/model/song"
+)
+
+// Chain asks providers in order and merges their answers: fields missing
+// from an earlier answer are filled from later ones. It stops once every
+// field is known.
+type Chain struct {
+	providers []MetadataProvider
+}
+
+func NewChain(providers ...MetadataProvider) *Chain {
+	return &Chain{
+		providers: providers,
+	}
+}
+
+// GetSongInfo returns the merged details if any provider knows the song.
+// Otherwise it returns the first error other than not found, so that callers
+// retry transient failures, or infoservice.ErrSongNotFound.
+func (c *Chain) GetSongInfo(ctx context.Context, song msong.Song) (*infoservice.SongDetail, error) {
+	var (
+		merged   *infoservice.SongDetail
+		firstErr error
+	)
+
+	for _, provider := range c.providers {
+		detail, err := provider.GetSongInfo(ctx, song)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			if firstErr == nil && !errors.Is(err, infoservice.ErrSongNotFound) {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		merged = merge(merged, detail)
+		if complete(merged) {
+			break
+		}
+	}
+
+	if merged != nil {
+		return merged, nil
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return nil, infoservice.ErrSongNotFound
+}
+
+func merge(into, from *infoservice.SongDetail) *infoservice.SongDetail {
+	if into == nil {
+		merged := *from
+		return &merged
+	}
+
+	if into.ReleaseDate == "" {
+		into.ReleaseDate = from.ReleaseDate
+	}
+
+	if into.Text == "" {
+		into.Text = from.Text
+	}
+
+	if into.Link == "" {
+		into.Link = from.Link
+	}
+
+	return into
+}
+
+func complete(detail *infoservice.SongDetail) bool {
+	return detail.ReleaseDate != "" && detail.Text != "" && detail.Link != ""
+}
@@ -0,0 +1,165 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/metadata"
+	"online-song-library/internal/metadata/mocks"
+	msong "online-song-library/internal/model/song"
+
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	song = msong.Song{Group: "Muse", Song: "Hysteria"}
+
+	errDown = errors.New("service down")
+	errBusy = errors.New("service busy")
+)
+
+// answer is what a provider of a chain returns. Providers after the last
+// answer must not be asked.
+type answer struct {
+	detail *infoservice.SongDetail
+	err    error
+}
+
+var chainTests = []struct {
+	name    string
+	answers []answer
+	// providers is the length of the chain, the answers by default.
+	providers int
+	want      *infoservice.SongDetail
+	wantErr   error
+}{
+	{
+		name: "complete answer stops the chain",
+		answers: []answer{
+			{detail: &infoservice.SongDetail{ReleaseDate: "16.07.2006", Text: "Ooh", Link: "https://a"}},
+		},
+		providers: 2,
+		want:      &infoservice.SongDetail{ReleaseDate: "16.07.2006", Text: "Ooh", Link: "https://a"},
+	},
+	{
+		name: "missing fields are filled from later answers",
+		answers: []answer{
+			{detail: &infoservice.SongDetail{ReleaseDate: "16.07.2006"}},
+			{detail: &infoservice.SongDetail{ReleaseDate: "01.01.2000", Text: "Ooh"}},
+			{detail: &infoservice.SongDetail{Text: "Other", Link: "https://c"}},
+		},
+		want: &infoservice.SongDetail{ReleaseDate: "16.07.2006", Text: "Ooh", Link: "https://c"},
+	},
+	{
+		name: "partial answer is returned",
+		answers: []answer{
+			{detail: &infoservice.SongDetail{Link: "https://a"}},
+			{err: infoservice.ErrSongNotFound},
+		},
+		want: &infoservice.SongDetail{Link: "https://a"},
+	},
+	{
+		name: "not found falls through",
+		answers: []answer{
+			{err: infoservice.ErrSongNotFound},
+			{detail: &infoservice.SongDetail{ReleaseDate: "16.07.2006", Text: "Ooh", Link: "https://b"}},
+		},
+		want: &infoservice.SongDetail{ReleaseDate: "16.07.2006", Text: "Ooh", Link: "https://b"},
+	},
+	{
+		name: "error falls through",
+		answers: []answer{
+			{err: errDown},
+			{detail: &infoservice.SongDetail{Text: "Ooh"}},
+		},
+		want: &infoservice.SongDetail{Text: "Ooh"},
+	},
+	{
+		name: "error wins over not found",
+		answers: []answer{
+			{err: infoservice.ErrSongNotFound},
+			{err: errDown},
+			{err: infoservice.ErrSongNotFound},
+		},
+		wantErr: errDown,
+	},
+	{
+		name: "first error wins",
+		answers: []answer{
+			{err: errDown},
+			{err: errBusy},
+		},
+		wantErr: errDown,
+	},
+	{
+		name: "nobody knows the song",
+		answers: []answer{
+			{err: infoservice.ErrSongNotFound},
+			{err: infoservice.ErrSongNotFound},
+		},
+		wantErr: infoservice.ErrSongNotFound,
+	},
+}
+
+func TestChain(t *testing.T) {
+	for _, tt := range chainTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			providers := make([]metadata.MetadataProvider, max(tt.providers, len(tt.answers)))
+			answered := make([]infoservice.SongDetail, len(tt.answers))
+
+			for i := range providers {
+				provider := mocks.NewMockMetadataProvider(ctrl)
+				providers[i] = provider
+
+				if i < len(tt.answers) {
+					provider.EXPECT().GetSongInfo(gomock.Any(), song).Return(tt.answers[i].detail, tt.answers[i].err)
+				}
+
+				if i < len(tt.answers) && tt.answers[i].detail != nil {
+					answered[i] = *tt.answers[i].detail
+				}
+			}
+
+			got, err := metadata.NewChain(providers...).GetSongInfo(context.Background(), song)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSongInfo() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSongInfo() = %+v, want %+v", got, tt.want)
+			}
+
+			// Merging must not change the answers of the providers.
+			for i, given := range tt.answers {
+				if given.detail != nil && *given.detail != answered[i] {
+					t.Errorf("answer %d changed to %+v", i, *given.detail)
+				}
+			}
+		})
+	}
+}
+
+func TestChainCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := gomock.NewController(t)
+
+	first := mocks.NewMockMetadataProvider(ctrl)
+	first.EXPECT().GetSongInfo(gomock.Any(), song).DoAndReturn(
+		func(context.Context, msong.Song) (*infoservice.SongDetail, error) {
+			cancel()
+
+			return nil, errDown
+		},
+	)
+
+	// The second provider is not asked once the caller has gone.
+	second := mocks.NewMockMetadataProvider(ctrl)
+
+	if _, err := metadata.NewChain(first, second).GetSongInfo(ctx, song); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSongInfo() error = %v, want %v", err, context.Canceled)
+	}
+}
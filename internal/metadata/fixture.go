@@ -0,0 +1,95 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"online-song-library/internal/clients/infoservice"
+	msong "online-song-library/internal/model/song"
+
+	"gopkg.in/yaml.v3"
+)
+
+type fixture struct {
+	Group       string `json:"group" yaml:"group"`
+	Song        string `json:"song" yaml:"song"`
+	ReleaseDate string `json:"releaseDate" yaml:"releaseDate"`
+	Text        string `json:"text" yaml:"text"`
+	Link        string `json:"link" yaml:"link"`
+}
+
+// FixtureProvider serves song details from .json, .yaml and .yml files in a
+// directory, each holding a list of songs. It is meant for offline
+// development and tests. Files are read once, on creation.
+type FixtureProvider struct {
+	details map[string]infoservice.SongDetail
+}
+
+func NewFixtureProvider(dir string) (*FixtureProvider, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture directory: %w", err)
+	}
+
+	fp := &FixtureProvider{
+		details: make(map[string]infoservice.SongDetail),
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if err := fp.load(filepath.Join(dir, entry.Name())); err != nil {
+			return nil, err
+		}
+	}
+
+	return fp, nil
+}
+
+func (fp *FixtureProvider) load(path string) error {
+	var unmarshal func([]byte, any) error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+		unmarshal = yaml.Unmarshal
+	default:
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read fixture: %w", err)
+	}
+
+	fixtures := []fixture{}
+	if err := unmarshal(data, &fixtures); err != nil {
+		return fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+
+	for _, f := range fixtures {
+		fp.details[infoservice.CacheKey(msong.Song{Group: f.Group, Song: f.Song})] = infoservice.SongDetail{
+			ReleaseDate: f.ReleaseDate,
+			Text:        f.Text,
+			Link:        f.Link,
+		}
+	}
+
+	return nil
+}
+
+func (fp *FixtureProvider) GetSongInfo(_ context.Context, song msong.Song) (*infoservice.SongDetail, error) {
+	detail, ok := fp.details[infoservice.CacheKey(song)]
+	if !ok {
+		return nil, infoservice.ErrSongNotFound
+	}
+
+	return &detail, nil
+}
@@ -0,0 +1,121 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/metadata"
+	msong "online-song-library/internal/model/song"
+)
+
+// writeFixtures creates a directory with the given files.
+func writeFixtures(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+var fixtureFiles = map[string]string{
+	"muse.json": `[{"group":"Muse","song":"Hysteria","releaseDate":"01.12.2003","text":"It's bugging me",` +
+		`"link":"https://example.com/hysteria"}]`,
+	"queen.yaml": "- group: Queen\n  song: Bohemian Rhapsody\n  releaseDate: 31.10.1975\n",
+	"notes.txt":  "not a fixture",
+}
+
+func TestFixtureProvider(t *testing.T) {
+	dir := writeFixtures(t, fixtureFiles)
+	if err := os.Mkdir(filepath.Join(dir, "nested.json"), 0o700); err != nil {
+		t.Fatalf("create nested directory: %v", err)
+	}
+
+	provider, err := metadata.NewFixtureProvider(dir)
+	if err != nil {
+		t.Fatalf("NewFixtureProvider() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		song    msong.Song
+		want    *infoservice.SongDetail
+		wantErr error
+	}{
+		{
+			name: "json",
+			song: msong.Song{Group: "Muse", Song: "Hysteria"},
+			want: &infoservice.SongDetail{
+				ReleaseDate: "01.12.2003",
+				Text:        "It's bugging me",
+				Link:        "https://example.com/hysteria",
+			},
+		},
+		{
+			name: "yaml with missing fields",
+			song: msong.Song{Group: "Queen", Song: "Bohemian Rhapsody"},
+			want: &infoservice.SongDetail{ReleaseDate: "31.10.1975"},
+		},
+		{
+			name: "case and spaces",
+			song: msong.Song{Group: " queen", Song: "BOHEMIAN RHAPSODY "},
+			want: &infoservice.SongDetail{ReleaseDate: "31.10.1975"},
+		},
+		{
+			name:    "unknown song",
+			song:    msong.Song{Group: "Muse", Song: "Uprising"},
+			wantErr: infoservice.ErrSongNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetSongInfo(context.Background(), tt.song)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSongInfo() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSongInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFixtureProviderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{
+			name: "missing directory",
+			dir:  filepath.Join(t.TempDir(), "missing"),
+		},
+		{
+			name: "malformed json",
+			dir:  writeFixtures(t, map[string]string{"broken.json": `{"group":`}),
+		},
+		{
+			name: "yaml that is not a list",
+			dir:  writeFixtures(t, map[string]string{"broken.yml": "group: Muse\n"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := metadata.NewFixtureProvider(tt.dir); err == nil {
+				t.Error("NewFixtureProvider() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/config"
+	msong "online-song-library/internal/model/song"
+)
+
+//go:generate mockgen -source=provider.go -destination=mocks/provider.go -package=mocks
+
+// MetadataProvider looks up song details by group and song name. Providers
+// return infoservice.ErrSongNotFound for songs they do not know and may leave
+// fields they have no data for empty.
+type MetadataProvider interface {
+	GetSongInfo(ctx context.Context, s msong.Song) (*infoservice.SongDetail, error)
+}
+
+// StatusReporter is implemented by providers backed by a remote service.
+type StatusReporter interface {
+	Status() infoservice.Status
+}
+
+// New builds the provider selected by cfg.MetadataProviders. Several names
+// make a chain that tries them in the listed order. Names are matched
+// ignoring case and surrounding spaces, empty ones are skipped.
+func New(cfg *config.Config, client *infoservice.Client) (MetadataProvider, error) {
+	providers := make([]MetadataProvider, 0, len(cfg.MetadataProviders))
+
+	for _, name := range cfg.MetadataProviders {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case "http":
+			providers = append(providers, client)
+		case "fixture":
+			fixture, err := NewFixtureProvider(cfg.MetadataFixtureDir)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, fixture)
+		default:
+			return nil, fmt.Errorf("unknown metadata provider %q", name)
+		}
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, fmt.Errorf("no metadata providers configured")
+	case 1:
+		return providers[0], nil
+	default:
+		return NewChain(providers...), nil
+	}
+}
+
+// StatusOf reports the status of the remote service behind provider, looking
+// into chains. ok is false when no provider calls a remote service.
+func StatusOf(provider MetadataProvider) (status infoservice.Status, ok bool) {
+	switch p := provider.(type) {
+	case StatusReporter:
+		return p.Status(), true
+	case *Chain:
+		for _, member := range p.providers {
+			if status, ok := StatusOf(member); ok {
+				return status, true
+			}
+		}
+	}
+
+	return infoservice.Status{}, false
+}
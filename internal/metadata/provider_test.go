@@ -0,0 +1,54 @@
+package metadata_test
+
+import (
+	"reflect"
+	"testing"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/config"
+	"online-song-library/internal/metadata"
+)
+
+func TestNew(t *testing.T) {
+	client := &infoservice.Client{}
+	dir := writeFixtures(t, fixtureFiles)
+
+	tests := []struct {
+		name      string
+		providers []string
+		// want is the type of the provider, empty for an error.
+		want reflect.Type
+	}{
+		{name: "http", providers: []string{"http"}, want: reflect.TypeOf(client)},
+		{name: "fixture", providers: []string{"fixture"}, want: reflect.TypeOf(&metadata.FixtureProvider{})},
+		{name: "chain", providers: []string{"fixture", "http"}, want: reflect.TypeOf(&metadata.Chain{})},
+		{name: "case and spaces", providers: []string{" HTTP", "Fixture "}, want: reflect.TypeOf(&metadata.Chain{})},
+		{name: "empty names are skipped", providers: []string{"http", " "}, want: reflect.TypeOf(client)},
+		{name: "unknown", providers: []string{"http", "ftp"}},
+		{name: "none", providers: []string{}},
+		{name: "only empty names", providers: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{MetadataProviders: tt.providers, MetadataFixtureDir: dir}
+
+			provider, err := metadata.New(cfg, client)
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("New() = %T, want an error", provider)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got := reflect.TypeOf(provider); got != tt.want {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
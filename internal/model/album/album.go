@@ -0,0 +1,41 @@
+package album
+
+import (
+	"time"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/model/song"
+)
+
+var (
+	ErrNotFound      = apperror.New(apperror.ErrNotFound, "album not found")
+	ErrTrackNotFound = apperror.New(apperror.ErrNotFound, "song is not on the album")
+)
+
+// Album is a release of an artist. Its release date stands in for the
+// release date of the songs on it that have none.
+type Album struct {
+	ID          uint64    `json:"id"`
+	Title       string    `json:"title"`
+	ArtistID    uint64    `json:"artistId"`
+	Artist      string    `json:"artist"`
+	ReleaseDate string    `json:"releaseDate"`
+	Link        string    `json:"link"`
+	TrackCount  int       `json:"trackCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// AlbumPage is one page of an album listing. Total counts all albums
+// matching the query, not only the ones on the page.
+type AlbumPage struct {
+	Albums []Album
+	Total  int
+}
+
+// Track is a song at its position on an album.
+type Track struct {
+	Disc  int `json:"disc"`
+	Track int `json:"track"`
+	song.Song
+}
@@ -0,0 +1,31 @@
+package artist
+
+import (
+	"time"
+
+	"online-song-library/internal/apperror"
+)
+
+var (
+	ErrNotFound = apperror.New(apperror.ErrNotFound, "artist not found")
+	ErrInUse    = apperror.New(apperror.ErrConflict, "artist still has songs or albums")
+)
+
+// Artist is a performer songs are grouped by. The group of a song is always
+// the name of its artist, any alias resolves to the same artist.
+type Artist struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	SortName  string    `json:"sortName"`
+	Aliases   []string  `json:"aliases"`
+	SongCount int       `json:"songCount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ArtistPage is one page of an artist listing. Total counts all artists
+// matching the query, not only the ones on the page.
+type ArtistPage struct {
+	Artists []Artist
+	Total   int
+}
@@ -0,0 +1,53 @@
+package playlist
+
+import (
+	"time"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/model/song"
+)
+
+var (
+	ErrNotFound     = apperror.New(apperror.ErrNotFound, "playlist not found")
+	ErrItemNotFound = apperror.New(apperror.ErrNotFound, "playlist item not found")
+)
+
+// Playlist is an ordered list of songs put together by its owner.
+type Playlist struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Owner       string    `json:"owner"`
+	Description string    `json:"description"`
+	ItemCount   int       `json:"itemCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PlaylistPage is one page of a playlist listing. Total counts all
+// playlists matching the query, not only the ones on the page.
+type PlaylistPage struct {
+	Playlists []Playlist
+	Total     int
+}
+
+// Item is a song on a playlist. A song can be on a playlist several times,
+// the item ID tells the entries apart. Position is 1-based.
+type Item struct {
+	ID       uint64    `json:"id"`
+	Position int       `json:"position"`
+	AddedAt  time.Time `json:"addedAt"`
+	Song     song.Song `json:"song"`
+}
+
+// Details is a playlist with its items in order.
+type Details struct {
+	Playlist
+	Items []Item `json:"items"`
+}
+
+// Placement says where an item goes: right before or right after another
+// item of the playlist, at the end if both are zero.
+type Placement struct {
+	Before uint64
+	After  uint64
+}
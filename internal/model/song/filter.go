@@ -0,0 +1,59 @@
+package song
+
+import (
+	"fmt"
+	"time"
+
+	"online-song-library/internal/apperror"
+)
+
+var ErrInvalidFilter = apperror.New(apperror.ErrBadRequest, "invalid filter")
+
+type Operator string
+
+const (
+	OpEq       Operator = "eq"
+	OpIlike    Operator = "ilike"
+	OpContains Operator = "contains"
+	OpPrefix   Operator = "prefix"
+	OpFrom     Operator = "from"
+	OpTo       Operator = "to"
+	// OpAny and OpAll match songs with any or all of a comma separated list of tags.
+	OpAny Operator = "any"
+	OpAll Operator = "all"
+)
+
+// Condition is a single predicate over an API field, e.g. group contains "beat".
+type Condition struct {
+	Field string
+	Op    Operator
+	Value string
+}
+
+// Filter is a conjunction of conditions.
+type Filter []Condition
+
+func (f Filter) And(field string, op Operator, value string) Filter {
+	return append(f, Condition{
+		Field: field,
+		Op:    op,
+		Value: value,
+	})
+}
+
+var releaseDateLayouts = []string{
+	"02.01.2006",
+	time.DateOnly,
+	time.RFC3339,
+}
+
+// ParseReleaseDate accepts dates in DD.MM.YYYY and ISO 8601 formats.
+func ParseReleaseDate(value string) (time.Time, error) {
+	for _, layout := range releaseDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date format: %q", value)
+}
@@ -0,0 +1,26 @@
+package song
+
+import "online-song-library/internal/apperror"
+
+// Import row statuses.
+const (
+	ImportAccepted  = "accepted"
+	ImportRejected  = "rejected"
+	ImportDuplicate = "duplicate"
+)
+
+// ImportRow is the outcome for one row of an import. Row is the 1-based
+// number of the record in the input, not counting a CSV header.
+type ImportRow struct {
+	Row    int                   `json:"row"`
+	Status string                `json:"status"`
+	ID     uint64                `json:"id,omitempty"`
+	Errors []apperror.FieldError `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	Accepted  int         `json:"accepted"`
+	Rejected  int         `json:"rejected"`
+	Duplicate int         `json:"duplicate"`
+	Rows      []ImportRow `json:"rows"`
+}
@@ -0,0 +1,57 @@
+package song
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"online-song-library/internal/apperror"
+)
+
+var ErrInvalidCursor = apperror.New(apperror.ErrBadRequest, "invalid cursor")
+
+// Page selects a slice of a listing either by a 1-based offset or, when
+// Cursor is set, by the position right after the last row of the previous page.
+type Page struct {
+	Offset int
+	Limit  int
+	Sort   Sort
+	Cursor *Cursor
+}
+
+// SongPage is one page of a song listing. Total counts all songs matching
+// the filter, not only the ones on the page.
+type SongPage struct {
+	Songs   []Song
+	Total   int
+	HasNext bool
+	Next    *Cursor
+}
+
+// Cursor is the keyset position of the last row returned to the client:
+// the values of its sort keys and its id. Sort records the order the cursor
+// was issued for, so it can't be replayed against a different one.
+type Cursor struct {
+	Sort string   `json:"sort,omitempty"`
+	Keys []string `json:"keys,omitempty"`
+	ID   uint64   `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := new(Cursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -1,16 +1,115 @@
 package song
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"online-song-library/internal/apperror"
+)
+
+var (
+	ErrNotFound        = apperror.New(apperror.ErrNotFound, "song not found")
+	ErrVersionMismatch = apperror.New(apperror.ErrPreconditionFailed, "song version does not match")
+)
+
+// Enrichment statuses tell whether the details from the music info service
+// have been filled in.
+const (
+	EnrichmentPending = "pending"
+	EnrichmentReady   = "ready"
+	EnrichmentFailed  = "failed"
+)
 
 type Song struct {
 	ID          uint64   `json:"id"`
 	Group       string   `json:"group"`
 	Song        string   `json:"song"`
 	ReleaseDate string   `json:"releaseDate"`
-	Verses      []string `json:"text"`
+	Verses      []string `json:"text,omitempty"`
 	Link        string   `json:"link"`
+
+	// Version is bumped on every change. On writes a non-zero Version is the
+	// version the client expects to overwrite.
+	Version int `json:"version,omitempty"`
+
+	EnrichmentStatus string `json:"enrichmentStatus,omitempty"`
+}
+
+// Details is the song metadata without its text.
+type Details struct {
+	Song
+	ArtistID   uint64    `json:"artistId"`
+	Tags       []string  `json:"tags"`
+	VerseCount int       `json:"verseCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// Verse is a song verse with its 1-based position in the text.
+type Verse struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+}
+
+// EnrichmentJob is a queued request to fill in the song details from the
+// music info service. Attempts includes the attempt in progress.
+type EnrichmentJob struct {
+	ID       uint64
+	Song     Song
+	Attempts int
+}
+
+// Patch holds the fields of a partial update, nil fields are left unchanged.
+type Patch struct {
+	Group       *string
+	Song        *string
+	ReleaseDate *string
+	Verses      *[]string
+	Link        *string
+
+	// Version, when non-zero, is the version the patch expects to apply to.
+	Version int
+}
+
+// IsHTTPLink reports whether link is an absolute http or https URL.
+func IsHTTPLink(link string) bool {
+	u, err := url.ParseRequestURI(link)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
 
 func SplitIntoVerses(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
 	return strings.Split(text, "\n\n")
 }
+
+type VerseMatch struct {
+	Verse   int    `json:"verse"`
+	Snippet string `json:"snippet"`
+}
+
+type SearchResult struct {
+	Song
+	Rank    float32      `json:"rank"`
+	Matches []VerseMatch `json:"matches"`
+}
+
+// Stats summarizes the library.
+type Stats struct {
+	Songs           int    `json:"songs"`
+	Groups          int    `json:"groups"`
+	Verses          int    `json:"verses"`
+	Pending         int    `json:"pending"`
+	Ready           int    `json:"ready"`
+	Failed          int    `json:"failed"`
+	QueuedJobs      int    `json:"queuedJobs"`
+	EarliestRelease string `json:"earliestRelease"`
+	LatestRelease   string `json:"latestRelease"`
+}
@@ -0,0 +1,59 @@
+package song
+
+import (
+	"strings"
+
+	"online-song-library/internal/apperror"
+)
+
+var ErrInvalidSort = apperror.New(apperror.ErrBadRequest, "invalid sort")
+
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Sort is an ordered list of sort keys. Its text form is a comma separated
+// list of field names, each optionally prefixed with "-" for descending order,
+// e.g. "-releaseDate,group".
+type Sort []SortKey
+
+func ParseSort(value string) (Sort, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+
+	sort := make(Sort, 0, len(parts))
+	for _, part := range parts {
+		key := SortKey{Field: strings.TrimSpace(part)}
+
+		if field, ok := strings.CutPrefix(key.Field, "-"); ok {
+			key = SortKey{Field: field, Desc: true}
+		} else {
+			key.Field = strings.TrimPrefix(key.Field, "+")
+		}
+
+		if key.Field == "" {
+			return nil, ErrInvalidSort
+		}
+
+		sort = append(sort, key)
+	}
+
+	return sort, nil
+}
+
+func (s Sort) String() string {
+	parts := make([]string, 0, len(s))
+	for _, key := range s {
+		if key.Desc {
+			parts = append(parts, "-"+key.Field)
+		} else {
+			parts = append(parts, key.Field)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,40 @@
+package song
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"online-song-library/internal/apperror"
+)
+
+// MaxTagLength is the longest tag name in characters.
+const MaxTagLength = 64
+
+var ErrTagNotFound = apperror.New(apperror.ErrNotFound, "song has no such tag")
+
+// TagCount is how many songs carry a tag.
+type TagCount struct {
+	Name  string `json:"name"`
+	Songs int    `json:"songs"`
+}
+
+// IsTagName reports whether name can be a tag: not blank, not too long and
+// without commas, which separate tags in filters.
+func IsTagName(name string) bool {
+	name = strings.TrimSpace(name)
+
+	return name != "" && utf8.RuneCountInString(name) <= MaxTagLength && !strings.Contains(name, ",")
+}
+
+// SplitTags splits a comma separated list of tags, dropping blank ones.
+func SplitTags(value string) []string {
+	tags := []string{}
+
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
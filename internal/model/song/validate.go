@@ -0,0 +1,98 @@
+package song
+
+import (
+	"unicode/utf8"
+
+	"online-song-library/internal/apperror"
+)
+
+const maxNameLength = 255
+
+// ValidateSong applies the rules of the create and update endpoints: group
+// and song are required and at most 255 characters long, a release date or
+// link, when given, must be valid.
+func ValidateSong(song Song) []apperror.FieldError {
+	fieldErrs := []apperror.FieldError{}
+
+	for _, field := range []struct{ name, value string }{
+		{"group", song.Group},
+		{"song", song.Song},
+	} {
+		if fieldErr, ok := checkName(field.name, field.value); !ok {
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+
+	if song.ReleaseDate != "" {
+		if fieldErr, ok := checkReleaseDate("releaseDate", song.ReleaseDate); !ok {
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+
+	if song.Link != "" {
+		if fieldErr, ok := checkLink("link", song.Link); !ok {
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+
+	return fieldErrs
+}
+
+// ValidatePatch applies the rules of the patch endpoint to the fields the
+// patch changes. Only the text can be cleared.
+func ValidatePatch(patch Patch) []apperror.FieldError {
+	fieldErrs := []apperror.FieldError{}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+		check func(name, value string) (apperror.FieldError, bool)
+	}{
+		{"group", patch.Group, checkName},
+		{"song", patch.Song, checkName},
+		{"releaseDate", patch.ReleaseDate, checkReleaseDate},
+		{"link", patch.Link, checkLink},
+	} {
+		switch {
+		case field.value == nil:
+		case *field.value == "":
+			fieldErrs = append(fieldErrs, apperror.FieldError{Field: field.name, Message: "is required"})
+		default:
+			if fieldErr, ok := field.check(field.name, *field.value); !ok {
+				fieldErrs = append(fieldErrs, fieldErr)
+			}
+		}
+	}
+
+	return fieldErrs
+}
+
+func checkName(name, value string) (apperror.FieldError, bool) {
+	switch {
+	case value == "":
+		return apperror.FieldError{Field: name, Message: "is required"}, false
+	case utf8.RuneCountInString(value) > maxNameLength:
+		return apperror.FieldError{Field: name, Message: "must be at most 255 characters long"}, false
+	default:
+		return apperror.FieldError{}, true
+	}
+}
+
+func checkReleaseDate(name, value string) (apperror.FieldError, bool) {
+	if _, err := ParseReleaseDate(value); err != nil {
+		return apperror.FieldError{
+			Field:   name,
+			Message: "must be a date in DD.MM.YYYY or YYYY-MM-DD format",
+		}, false
+	}
+
+	return apperror.FieldError{}, true
+}
+
+func checkLink(name, value string) (apperror.FieldError, bool) {
+	if !IsHTTPLink(value) {
+		return apperror.FieldError{Field: name, Message: "must be an http or https URL"}, false
+	}
+
+	return apperror.FieldError{}, true
+}
@@ -0,0 +1,83 @@
+package song_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+)
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestValidateSong(t *testing.T) {
+	tests := []struct {
+		name string
+		song msong.Song
+		want []string
+	}{
+		{name: "valid", song: msong.Song{Group: "Muse", Song: "Hysteria", ReleaseDate: "01.12.2003"}},
+		{name: "missing names", song: msong.Song{}, want: []string{"group", "song"}},
+		{name: "long name", song: msong.Song{Group: strings.Repeat("a", 256), Song: "Hysteria"}, want: []string{"group"}},
+		{
+			name: "invalid date and link",
+			song: msong.Song{Group: "Muse", Song: "Hysteria", ReleaseDate: "2003", Link: "ftp://example.com"},
+			want: []string{"releaseDate", "link"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(msong.ValidateSong(tt.song)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateSong() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch msong.Patch
+		want  []string
+	}{
+		{name: "empty", patch: msong.Patch{}},
+		{name: "valid", patch: msong.Patch{Song: ptr("Hysteria"), Link: ptr("https://example.com")}},
+		{name: "text can be cleared", patch: msong.Patch{Verses: &[]string{}}},
+		{
+			name:  "other fields cannot",
+			patch: msong.Patch{Group: ptr(""), Song: ptr(""), ReleaseDate: ptr(""), Link: ptr("")},
+			want:  []string{"group", "song", "releaseDate", "link"},
+		},
+		{
+			name: "invalid values",
+			patch: msong.Patch{
+				Group:       ptr(strings.Repeat("a", 256)),
+				ReleaseDate: ptr("31.02.2003"),
+				Link:        ptr("example.com"),
+			},
+			want: []string{"group", "releaseDate", "link"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(msong.ValidatePatch(tt.patch)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePatch() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fields returns the names of the fields in fieldErrs, nil if there are none.
+func fields(fieldErrs []apperror.FieldError) []string {
+	var names []string
+	for _, fieldErr := range fieldErrs {
+		names = append(names, fieldErr.Field)
+	}
+
+	return names
+}
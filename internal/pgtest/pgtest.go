@@ -0,0 +1,154 @@
+// Package pgtest runs tests against a real Postgres given by PG_TEST_DSN.
+//
+// The first call to New in a test binary recreates the schema of that binary
+// in the database and applies the embedded migrations to it, so the schema in
+// use always matches the migrations directory. go test runs the binaries of
+// different packages in parallel, so each one gets its own schema, named after
+// the package: pgtest_songrepository for songrepository.test. Every call
+// truncates all tables. Tests are skipped when PG_TEST_DSN is not set.
+package pgtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"online-song-library/internal/bootstrap"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	dsnEnv = "PG_TEST_DSN"
+
+	// maxIdentifierLength is the longest name Postgres keeps without truncating.
+	maxIdentifierLength = 63
+)
+
+var (
+	schema = schemaName(os.Args[0])
+
+	setupOnce sync.Once
+	setupErr  error
+)
+
+// New returns a pool connected to the schema of the test binary with empty tables.
+// The pool is closed when the test ends.
+func New(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", dsnEnv)
+	}
+
+	ctx := context.Background()
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		tb.Fatalf("parse %s: %v", dsnEnv, err)
+	}
+
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		tb.Fatalf("connect to postgres: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+
+	setupOnce.Do(func() {
+		setupErr = setup(ctx, pool)
+	})
+	if setupErr != nil {
+		tb.Fatalf("set up test schema: %v", setupErr)
+	}
+
+	if err := truncate(ctx, pool); err != nil {
+		tb.Fatalf("truncate tables: %v", err)
+	}
+
+	return pool
+}
+
+// schemaName derives the schema of a test binary from its path. Characters
+// other than letters, digits and underscores are replaced, so the name can be
+// used in SQL without quoting.
+func schemaName(binary string) string {
+	name := strings.TrimSuffix(filepath.Base(binary), ".exe")
+	name = strings.TrimSuffix(name, ".test")
+
+	name = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, strings.ToLower(name))
+
+	name = "pgtest_" + name
+	if len(name) > maxIdentifierLength {
+		name = name[:maxIdentifierLength]
+	}
+
+	return name
+}
+
+func setup(ctx context.Context, pool *pgxpool.Pool) error {
+	if err := exec(ctx, pool, func(conn *pgx.Conn) error {
+		return simpleExec(ctx, conn, fmt.Sprintf(
+			"drop schema if exists %[1]s cascade; create schema %[1]s;", schema,
+		))
+	}); err != nil {
+		return err
+	}
+
+	_, err := bootstrap.MigrateUp(ctx, pool)
+
+	return err
+}
+
+func truncate(ctx context.Context, pool *pgxpool.Pool) error {
+	const sql = `
+	select
+		coalesce(string_agg(format('%I', tablename), ', '), '')
+	from pg_tables
+	where schemaname = $1;
+	`
+
+	var tables string
+	if err := pool.QueryRow(ctx, sql, schema).Scan(&tables); err != nil {
+		return err
+	}
+
+	if tables == "" {
+		return nil
+	}
+
+	return exec(ctx, pool, func(conn *pgx.Conn) error {
+		return simpleExec(ctx, conn, "truncate "+tables+" restart identity cascade;")
+	})
+}
+
+func exec(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgx.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	return fn(conn.Conn())
+}
+
+// simpleExec runs sql over the simple query protocol, which allows several
+// statements in one call.
+func simpleExec(ctx context.Context, conn *pgx.Conn, sql string) error {
+	_, err := conn.PgConn().Exec(ctx, sql).ReadAll()
+
+	return err
+}
@@ -0,0 +1,450 @@
+package albumrepository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	malbum "online-song-library/internal/model/album"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/pgerror"
+	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type AlbumRepository struct {
+	store dbstore.DB
+}
+
+func NewAlbumRepository(store dbstore.DB) *AlbumRepository {
+	return &AlbumRepository{
+		store: store,
+	}
+}
+
+// List returns a page of albums by release date. A non-zero artistID keeps
+// the albums of that artist, a non-empty title those whose title contains it.
+func (ar *AlbumRepository) List(
+	ctx context.Context,
+	artistID uint64,
+	title string,
+	offset, limit int,
+) (*malbum.AlbumPage, error) {
+	const sql = `
+	select
+		albums.id,
+		albums.title,
+		albums.artist_id,
+		artists.name,
+		coalesce(to_char(albums.release_date, 'DD.MM.YYYY'), ''),
+		albums.link,
+		(select count(*) from album_tracks where album_id = albums.id),
+		albums.created_at,
+		albums.updated_at,
+		count(*) over ()
+	from albums
+	join artists on artists.id = albums.artist_id
+	where ` + listFilter + `
+	order by albums.release_date nulls last, lower(albums.title), albums.id
+	offset $4
+	limit $5;
+	`
+
+	pattern := "%" + likeEscaper.Replace(title) + "%"
+
+	rows, err := ar.store.Query(ctx, sql, artistID, title, pattern, offset-1, limit)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	result := &malbum.AlbumPage{
+		Albums: []malbum.Album{},
+	}
+
+	for rows.Next() {
+		album := malbum.Album{}
+		if err := rows.Scan(
+			&album.ID,
+			&album.Title,
+			&album.ArtistID,
+			&album.Artist,
+			&album.ReleaseDate,
+			&album.Link,
+			&album.TrackCount,
+			&album.CreatedAt,
+			&album.UpdatedAt,
+			&result.Total,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		result.Albums = append(result.Albums, album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	// Past the end there are no rows to carry the window count.
+	if len(result.Albums) == 0 && offset > 1 {
+		if result.Total, err = ar.count(ctx, artistID, title, pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// listFilter keeps the albums matching the artist ID given to List as $1
+// and the title given as $2 and as a LIKE pattern in $3.
+const listFilter = `($1 = 0 or albums.artist_id = $1)
+		and ($2 = '' or albums.title ilike $3)`
+
+func (ar *AlbumRepository) count(ctx context.Context, artistID uint64, title, pattern string) (int, error) {
+	const sql = `
+	select
+		count(*)
+	from albums
+	where ` + listFilter + `;
+	`
+
+	var total int
+	if err := ar.store.QueryRow(ctx, sql, artistID, title, pattern).Scan(&total); err != nil {
+		return 0, mapError(err)
+	}
+
+	return total, nil
+}
+
+func (ar *AlbumRepository) GetByID(ctx context.Context, id uint64) (*malbum.Album, error) {
+	const sql = `
+	select
+		albums.id,
+		albums.title,
+		albums.artist_id,
+		artists.name,
+		coalesce(to_char(albums.release_date, 'DD.MM.YYYY'), ''),
+		albums.link,
+		(select count(*) from album_tracks where album_id = albums.id),
+		albums.created_at,
+		albums.updated_at
+	from albums
+	join artists on artists.id = albums.artist_id
+	where albums.id = $1;
+	`
+
+	album := new(malbum.Album)
+
+	if err := ar.store.QueryRow(
+		ctx,
+		sql,
+		id,
+	).Scan(
+		&album.ID,
+		&album.Title,
+		&album.ArtistID,
+		&album.Artist,
+		&album.ReleaseDate,
+		&album.Link,
+		&album.TrackCount,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	); err != nil {
+		return nil, mapError(err)
+	}
+
+	return album, nil
+}
+
+// Create adds the album. The artist is resolved by name or alias the way
+// song groups are, an unknown one is created.
+func (ar *AlbumRepository) Create(ctx context.Context, album malbum.Album) (uint64, error) {
+	releaseDate, err := releaseDateArg(album.ReleaseDate)
+	if err != nil {
+		return 0, err
+	}
+
+	const sql = `
+	insert into albums(
+		title,
+		artist_id,
+		release_date,
+		link
+	) values ($1, (resolve_artist($2)).id, $3, $4)
+	returning id;
+	`
+
+	var id uint64
+	if err := ar.store.QueryRow(
+		ctx,
+		sql,
+		album.Title,
+		album.Artist,
+		releaseDate,
+		album.Link,
+	).Scan(
+		&id,
+	); err != nil {
+		return 0, mapError(err)
+	}
+
+	return id, nil
+}
+
+// Update replaces the album. A new release date is inherited by the tracks
+// without a release date of their own, which bumps their versions.
+func (ar *AlbumRepository) Update(ctx context.Context, album malbum.Album) error {
+	releaseDate, err := releaseDateArg(album.ReleaseDate)
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, ar.store, func(tx pgx.Tx) error {
+		const sql = `
+		update
+			albums
+		set
+			title = $1,
+			artist_id = (resolve_artist($2)).id,
+			release_date = $3,
+			link = $4,
+			updated_at = now()
+		from (select release_date from albums where id = $5) as previous
+		where albums.id = $5
+		returning previous.release_date is distinct from albums.release_date;
+		`
+
+		var dateChanged bool
+		if err := tx.QueryRow(
+			ctx,
+			sql,
+			album.Title,
+			album.Artist,
+			releaseDate,
+			album.Link,
+			album.ID,
+		).Scan(
+			&dateChanged,
+		); err != nil {
+			return mapError(err)
+		}
+
+		if !dateChanged {
+			return nil
+		}
+
+		return bumpInheritingSongs(ctx, tx, album.ID, 0, true)
+	})
+}
+
+// Delete removes the album with its track list, the songs are kept. Tracks
+// that inherited the album release date lose it, which bumps their versions.
+func (ar *AlbumRepository) Delete(ctx context.Context, id uint64) error {
+	return pgx.BeginFunc(ctx, ar.store, func(tx pgx.Tx) error {
+		if err := bumpInheritingSongs(ctx, tx, id, 0, false); err != nil {
+			return err
+		}
+
+		const sql = `
+		delete from albums
+		where id = $1;
+		`
+
+		tag, err := tx.Exec(ctx, sql, id)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return malbum.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// Tracks lists the songs of the album by disc and track number.
+func (ar *AlbumRepository) Tracks(ctx context.Context, id uint64) ([]malbum.Track, error) {
+	const sql = `
+	select
+		album_tracks.disc,
+		album_tracks.track,
+		s.id,
+		s."group",
+		s.song,
+		coalesce(to_char(s.release_date, 'DD.MM.YYYY'), ''),
+		s.link
+	from album_tracks
+	join song_listing s on s.id = album_tracks.song_id
+	where album_tracks.album_id = $1
+	order by album_tracks.disc, album_tracks.track;
+	`
+
+	rows, err := ar.store.Query(ctx, sql, id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	tracks := []malbum.Track{}
+
+	for rows.Next() {
+		track := malbum.Track{}
+		if err := rows.Scan(
+			&track.Disc,
+			&track.Track,
+			&track.ID,
+			&track.Group,
+			&track.Song.Song,
+			&track.ReleaseDate,
+			&track.Link,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	if len(tracks) == 0 {
+		if _, err := ar.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return tracks, nil
+}
+
+// SetTrack puts the song on the album at the given position, moving it if
+// it is already there. A song without a release date of its own may inherit
+// the album one, so its version is bumped.
+func (ar *AlbumRepository) SetTrack(ctx context.Context, albumID uint64, track malbum.Track) error {
+	err := pgx.BeginFunc(ctx, ar.store, func(tx pgx.Tx) error {
+		const sql = `
+		insert into album_tracks(
+			album_id,
+			song_id,
+			disc,
+			track
+		)
+		select $1, $2, $3, $4
+		where exists (select 1 from albums where id = $1)
+			and exists (select 1 from songs where id = $2)
+		on conflict (album_id, song_id) do update
+		set
+			disc = excluded.disc,
+			track = excluded.track;
+		`
+
+		tag, err := tx.Exec(ctx, sql, albumID, track.ID, track.Disc, track.Track)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return msong.ErrNotFound
+		}
+
+		return bumpInheritingSongs(ctx, tx, albumID, track.ID, false)
+	})
+	if errors.Is(err, msong.ErrNotFound) {
+		if _, err := ar.GetByID(ctx, albumID); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// RemoveTrack takes the song off the album. A song that inherited the album
+// release date may lose it, so its version is bumped.
+func (ar *AlbumRepository) RemoveTrack(ctx context.Context, albumID, songID uint64) error {
+	err := pgx.BeginFunc(ctx, ar.store, func(tx pgx.Tx) error {
+		if err := bumpInheritingSongs(ctx, tx, albumID, songID, false); err != nil {
+			return err
+		}
+
+		const sql = `
+		delete from album_tracks
+		where album_id = $1 and song_id = $2;
+		`
+
+		tag, err := tx.Exec(ctx, sql, albumID, songID)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return malbum.ErrTrackNotFound
+		}
+
+		return nil
+	})
+	if errors.Is(err, malbum.ErrTrackNotFound) {
+		if _, err := ar.GetByID(ctx, albumID); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// bumpInheritingSongs bumps the versions of the album tracks, or of the one
+// with a non-zero songID, that have no release date of their own and so list
+// the earliest date of their albums. Unless dateChanged tells the album
+// release date has just changed, an album without one is left alone.
+func bumpInheritingSongs(ctx context.Context, tx pgx.Tx, albumID, songID uint64, dateChanged bool) error {
+	const sql = `
+	update
+		songs
+	set
+		updated_at = now(),
+		version = version + 1
+	where release_date is null
+		and id in (
+			select song_id
+			from album_tracks
+			where album_id = $1 and ($2::bigint = 0 or song_id = $2)
+		)
+		and (
+			$3
+			or exists (
+				select 1
+				from albums
+				where id = $1 and release_date is not null
+			)
+		);
+	`
+
+	if _, err := tx.Exec(ctx, sql, albumID, songID, dateChanged); err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+func releaseDateArg(value string) (any, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := msong.ParseReleaseDate(value)
+	if err != nil {
+		return nil, apperror.New(apperror.ErrValidation, err.Error())
+	}
+
+	return date, nil
+}
+
+// mapError translates driver errors into domain errors.
+func mapError(err error) error {
+	return pgerror.Map(err, malbum.ErrNotFound)
+}
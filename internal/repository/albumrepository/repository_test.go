@@ -0,0 +1,190 @@
+package albumrepository_test
+
+import (
+	"context"
+	"testing"
+
+	malbum "online-song-library/internal/model/album"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/pgtest"
+	"online-song-library/internal/repository/albumrepository"
+	"online-song-library/internal/repository/songrepository"
+)
+
+type fixture struct {
+	albums *albumrepository.AlbumRepository
+	songs  *songrepository.SongRepository
+	album  malbum.Album
+	// inheriting has no release date of its own, dated has one.
+	inheriting uint64
+	dated      uint64
+}
+
+// newFixture stores an album with two tracks over an empty test database.
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	pool := pgtest.New(t)
+	f := &fixture{
+		albums: albumrepository.NewAlbumRepository(pool),
+		songs:  songrepository.NewSongRepository(pool),
+		album:  malbum.Album{Title: "Absolution", Artist: "Muse", ReleaseDate: "2003-09-15"},
+	}
+
+	var err error
+	if f.album.ID, err = f.albums.Create(ctx, f.album); err != nil {
+		t.Fatalf("Create(album) error = %v", err)
+	}
+
+	for i, song := range []msong.Song{
+		{Group: "Muse", Song: "Hysteria"},
+		{Group: "Muse", Song: "Time Is Running Out", ReleaseDate: "2003-09-08"},
+	} {
+		song.Verses = []string{}
+
+		id, err := f.songs.Create(ctx, song)
+		if err != nil {
+			t.Fatalf("Create(%s) error = %v", song.Song, err)
+		}
+
+		f.setTrack(t, id, i+1)
+
+		if song.ReleaseDate == "" {
+			f.inheriting = id
+		} else {
+			f.dated = id
+		}
+	}
+
+	return f
+}
+
+func (f *fixture) setTrack(t *testing.T, songID uint64, track int) {
+	t.Helper()
+
+	err := f.albums.SetTrack(context.Background(), f.album.ID, malbum.Track{
+		Disc:  1,
+		Track: track,
+		Song:  msong.Song{ID: songID},
+	})
+	if err != nil {
+		t.Fatalf("SetTrack(%d) error = %v", songID, err)
+	}
+}
+
+// checkVersions compares the versions of the inheriting and the dated song.
+func (f *fixture) checkVersions(t *testing.T, step string, inheriting, dated int) {
+	t.Helper()
+
+	for id, want := range map[uint64]int{f.inheriting: inheriting, f.dated: dated} {
+		details, err := f.songs.GetByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("%s: GetByID(%d) error = %v", step, id, err)
+		}
+
+		if details.Version != want {
+			t.Errorf("%s: song %d version = %d, want %d", step, id, details.Version, want)
+		}
+	}
+}
+
+func TestAlbumChangesBumpInheritingSongs(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	f.checkVersions(t, "set track", 2, 1)
+
+	f.album.Title = "Absolution XX"
+	if err := f.albums.Update(ctx, f.album); err != nil {
+		t.Fatalf("Update(title) error = %v", err)
+	}
+
+	f.checkVersions(t, "update title", 2, 1)
+
+	f.album.ReleaseDate = "2003-09-22"
+	if err := f.albums.Update(ctx, f.album); err != nil {
+		t.Fatalf("Update(release date) error = %v", err)
+	}
+
+	f.checkVersions(t, "update release date", 3, 1)
+
+	f.album.ReleaseDate = ""
+	if err := f.albums.Update(ctx, f.album); err != nil {
+		t.Fatalf("Update(no release date) error = %v", err)
+	}
+
+	f.checkVersions(t, "clear release date", 4, 1)
+
+	f.album.ReleaseDate = "2003-09-15"
+	if err := f.albums.Update(ctx, f.album); err != nil {
+		t.Fatalf("Update(release date again) error = %v", err)
+	}
+
+	f.checkVersions(t, "restore release date", 5, 1)
+
+	if err := f.albums.RemoveTrack(ctx, f.album.ID, f.inheriting); err != nil {
+		t.Fatalf("RemoveTrack() error = %v", err)
+	}
+
+	f.checkVersions(t, "remove track", 6, 1)
+	f.setTrack(t, f.inheriting, 1)
+
+	if err := f.albums.Delete(ctx, f.album.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	f.checkVersions(t, "delete", 8, 1)
+
+	details, err := f.songs.GetByID(ctx, f.inheriting)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if details.ReleaseDate != "" {
+		t.Errorf("ReleaseDate after delete = %q, want none", details.ReleaseDate)
+	}
+}
+
+func TestListTotal(t *testing.T) {
+	ctx := context.Background()
+	albums := albumrepository.NewAlbumRepository(pgtest.New(t))
+
+	for _, album := range []malbum.Album{
+		{Title: "Absolution", Artist: "Muse"},
+		{Title: "Origin of Symmetry", Artist: "Muse"},
+		{Title: "A Night at the Opera", Artist: "Queen"},
+	} {
+		if _, err := albums.Create(ctx, album); err != nil {
+			t.Fatalf("Create(%s) error = %v", album.Title, err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		title         string
+		offset, limit int
+		wantAlbums    int
+		wantTotal     int
+	}{
+		{name: "first page", offset: 1, limit: 2, wantAlbums: 2, wantTotal: 3},
+		{name: "last page", offset: 3, limit: 2, wantAlbums: 1, wantTotal: 3},
+		{name: "past the end", offset: 10, limit: 2, wantTotal: 3},
+		{name: "filtered past the end", title: "opera", offset: 5, limit: 2, wantTotal: 1},
+		{name: "no match", title: "abba", offset: 1, limit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := albums.List(ctx, 0, tt.title, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(page.Albums) != tt.wantAlbums || page.Total != tt.wantTotal {
+				t.Errorf("List() = %d albums of %d, want %d of %d",
+					len(page.Albums), page.Total, tt.wantAlbums, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package artistrepository
+
+import (
+	"context"
+	"strings"
+
+	martist "online-song-library/internal/model/artist"
+	"online-song-library/internal/repository/pgerror"
+	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type ArtistRepository struct {
+	store dbstore.DB
+}
+
+func NewArtistRepository(store dbstore.DB) *ArtistRepository {
+	return &ArtistRepository{
+		store: store,
+	}
+}
+
+// List returns a page of artists in sort name order. A non-empty name keeps
+// the artists whose name or one of the aliases contains it.
+func (ar *ArtistRepository) List(
+	ctx context.Context,
+	name string,
+	offset, limit int,
+) (*martist.ArtistPage, error) {
+	const sql = `
+	select
+		id,
+		name,
+		sort_name,
+		aliases,
+		(select count(*) from songs where artist_id = artists.id),
+		created_at,
+		updated_at,
+		count(*) over ()
+	from artists
+	where ` + nameFilter + `
+	order by lower(sort_name), id
+	offset $3
+	limit $4;
+	`
+
+	pattern := "%" + likeEscaper.Replace(name) + "%"
+
+	rows, err := ar.store.Query(
+		ctx,
+		sql,
+		name,
+		pattern,
+		offset-1,
+		limit,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	result := &martist.ArtistPage{
+		Artists: []martist.Artist{},
+	}
+
+	for rows.Next() {
+		artist := martist.Artist{}
+		if err := rows.Scan(
+			&artist.ID,
+			&artist.Name,
+			&artist.SortName,
+			&artist.Aliases,
+			&artist.SongCount,
+			&artist.CreatedAt,
+			&artist.UpdatedAt,
+			&result.Total,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		result.Artists = append(result.Artists, artist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	// Past the end there are no rows to carry the window count.
+	if len(result.Artists) == 0 && offset > 1 {
+		if result.Total, err = ar.count(ctx, name, pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// nameFilter keeps the artists matching the name given to List as $1 and
+// as a LIKE pattern in $2.
+const nameFilter = `($1 = ''
+		or name ilike $2
+		or exists (select 1 from unnest(aliases) as alias where alias ilike $2))`
+
+func (ar *ArtistRepository) count(ctx context.Context, name, pattern string) (int, error) {
+	const sql = `
+	select
+		count(*)
+	from artists
+	where ` + nameFilter + `;
+	`
+
+	var total int
+	if err := ar.store.QueryRow(ctx, sql, name, pattern).Scan(&total); err != nil {
+		return 0, mapError(err)
+	}
+
+	return total, nil
+}
+
+func (ar *ArtistRepository) GetByID(ctx context.Context, id uint64) (*martist.Artist, error) {
+	const sql = `
+	select
+		id,
+		name,
+		sort_name,
+		aliases,
+		(select count(*) from songs where artist_id = artists.id),
+		created_at,
+		updated_at
+	from artists
+	where id = $1;
+	`
+
+	artist := new(martist.Artist)
+
+	if err := ar.store.QueryRow(
+		ctx,
+		sql,
+		id,
+	).Scan(
+		&artist.ID,
+		&artist.Name,
+		&artist.SortName,
+		&artist.Aliases,
+		&artist.SongCount,
+		&artist.CreatedAt,
+		&artist.UpdatedAt,
+	); err != nil {
+		return nil, mapError(err)
+	}
+
+	return artist, nil
+}
+
+// Create adds the artist, an empty sort name is derived from the name.
+func (ar *ArtistRepository) Create(ctx context.Context, artist martist.Artist) (uint64, error) {
+	const sql = `
+	insert into artists(
+		name,
+		sort_name,
+		aliases
+	) values ($1, coalesce(nullif($2, ''), artist_sort_name($1)), $3)
+	returning id;
+	`
+
+	var id uint64
+	if err := ar.store.QueryRow(
+		ctx,
+		sql,
+		artist.Name,
+		artist.SortName,
+		aliasesArg(artist.Aliases),
+	).Scan(
+		&id,
+	); err != nil {
+		return 0, mapError(err)
+	}
+
+	return id, nil
+}
+
+// Update replaces the artist. A new name is carried over to the group of
+// all its songs, which bumps their versions.
+func (ar *ArtistRepository) Update(ctx context.Context, artist martist.Artist) error {
+	return pgx.BeginFunc(ctx, ar.store, func(tx pgx.Tx) error {
+		const sql = `
+		update
+			artists
+		set
+			name = $1,
+			sort_name = coalesce(nullif($2, ''), artist_sort_name($1)),
+			aliases = $3,
+			updated_at = now()
+		where id = $4;
+		`
+
+		tag, err := tx.Exec(
+			ctx,
+			sql,
+			artist.Name,
+			artist.SortName,
+			aliasesArg(artist.Aliases),
+			artist.ID,
+		)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return martist.ErrNotFound
+		}
+
+		const renameSQL = `
+		update
+			songs
+		set
+			"group" = $1,
+			updated_at = now(),
+			version = version + 1
+		where artist_id = $2 and "group" <> $1;
+		`
+
+		if _, err := tx.Exec(ctx, renameSQL, artist.Name, artist.ID); err != nil {
+			return mapError(err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes an artist that has no songs or albums left.
+func (ar *ArtistRepository) Delete(ctx context.Context, id uint64) error {
+	const sql = `
+	delete from artists
+	where id = $1
+		and not exists (select 1 from songs where artist_id = $1)
+		and not exists (select 1 from albums where artist_id = $1);
+	`
+
+	tag, err := ar.store.Exec(ctx, sql, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := ar.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		return martist.ErrInUse
+	}
+
+	return nil
+}
+
+func aliasesArg(aliases []string) []string {
+	if aliases == nil {
+		return []string{}
+	}
+
+	return aliases
+}
+
+// mapError translates driver errors into domain errors.
+func mapError(err error) error {
+	return pgerror.Map(err, martist.ErrNotFound)
+}
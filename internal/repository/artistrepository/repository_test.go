@@ -0,0 +1,53 @@
+package artistrepository_test
+
+import (
+	"context"
+	"testing"
+
+	martist "online-song-library/internal/model/artist"
+	"online-song-library/internal/pgtest"
+	"online-song-library/internal/repository/artistrepository"
+)
+
+func TestListTotal(t *testing.T) {
+	ctx := context.Background()
+	repo := artistrepository.NewArtistRepository(pgtest.New(t))
+
+	for _, artist := range []martist.Artist{
+		{Name: "Muse", Aliases: []string{}},
+		{Name: "Queen", Aliases: []string{}},
+		{Name: "The Beatles", Aliases: []string{"Fab Four"}},
+	} {
+		if _, err := repo.Create(ctx, artist); err != nil {
+			t.Fatalf("Create(%s) error = %v", artist.Name, err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		filter        string
+		offset, limit int
+		wantArtists   int
+		wantTotal     int
+	}{
+		{name: "first page", offset: 1, limit: 2, wantArtists: 2, wantTotal: 3},
+		{name: "last page", offset: 3, limit: 2, wantArtists: 1, wantTotal: 3},
+		{name: "past the end", offset: 10, limit: 2, wantTotal: 3},
+		{name: "filtered past the end", filter: "fab", offset: 5, limit: 2, wantTotal: 1},
+		{name: "no match", filter: "abba", offset: 1, limit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, tt.filter, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(page.Artists) != tt.wantArtists || page.Total != tt.wantTotal {
+				t.Errorf("List() = %d artists of %d, want %d of %d",
+					len(page.Artists), page.Total, tt.wantArtists, tt.wantTotal)
+			}
+		})
+	}
+}
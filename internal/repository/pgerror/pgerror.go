@@ -0,0 +1,45 @@
+// Package pgerror translates Postgres driver errors into apperror kinds for
+// the repositories.
+package pgerror
+
+import (
+	"errors"
+
+	"online-song-library/internal/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	codeNotNullViolation      = "23502"
+	codeForeignKeyViolation   = "23503"
+	codeUniqueViolation       = "23505"
+	codeCheckViolation        = "23514"
+	codeInvalidDatetimeFormat = "22007"
+	codeDatetimeFieldOverflow = "22008"
+)
+
+// Map translates driver errors into domain errors, notFound stands for
+// pgx.ErrNoRows.
+func Map(err error, notFound error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case codeUniqueViolation, codeForeignKeyViolation:
+		return apperror.New(apperror.ErrConflict, pgErr.Message)
+	case codeInvalidDatetimeFormat, codeDatetimeFieldOverflow,
+		codeNotNullViolation, codeCheckViolation:
+		return apperror.New(apperror.ErrValidation, pgErr.Message)
+	}
+
+	return err
+}
@@ -0,0 +1,503 @@
+package playlistrepository
+
+import (
+	"context"
+	"errors"
+
+	mplaylist "online-song-library/internal/model/playlist"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/pgerror"
+	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PlaylistRepository struct {
+	store dbstore.DB
+}
+
+func NewPlaylistRepository(store dbstore.DB) *PlaylistRepository {
+	return &PlaylistRepository{
+		store: store,
+	}
+}
+
+// List returns a page of playlists, the most recently changed first.
+// A non-empty owner keeps the playlists of that owner.
+func (pr *PlaylistRepository) List(
+	ctx context.Context,
+	owner string,
+	offset, limit int,
+) (*mplaylist.PlaylistPage, error) {
+	const sql = `
+	select
+		id,
+		name,
+		owner,
+		description,
+		(select count(*) from playlist_items where playlist_id = playlists.id),
+		created_at,
+		updated_at,
+		count(*) over ()
+	from playlists
+	where $1 = '' or owner = $1
+	order by updated_at desc, id
+	offset $2
+	limit $3;
+	`
+
+	rows, err := pr.store.Query(ctx, sql, owner, offset-1, limit)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	result := &mplaylist.PlaylistPage{
+		Playlists: []mplaylist.Playlist{},
+	}
+
+	for rows.Next() {
+		playlist := mplaylist.Playlist{}
+		if err := rows.Scan(
+			&playlist.ID,
+			&playlist.Name,
+			&playlist.Owner,
+			&playlist.Description,
+			&playlist.ItemCount,
+			&playlist.CreatedAt,
+			&playlist.UpdatedAt,
+			&result.Total,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		result.Playlists = append(result.Playlists, playlist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	// Past the end there are no rows to carry the window count.
+	if len(result.Playlists) == 0 && offset > 1 {
+		if result.Total, err = pr.count(ctx, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (pr *PlaylistRepository) count(ctx context.Context, owner string) (int, error) {
+	const sql = `
+	select
+		count(*)
+	from playlists
+	where $1 = '' or owner = $1;
+	`
+
+	var total int
+	if err := pr.store.QueryRow(ctx, sql, owner).Scan(&total); err != nil {
+		return 0, mapError(err)
+	}
+
+	return total, nil
+}
+
+// GetByID returns the playlist with its items in order and the metadata of
+// their songs.
+func (pr *PlaylistRepository) GetByID(ctx context.Context, id uint64) (*mplaylist.Details, error) {
+	const sql = `
+	select
+		id,
+		name,
+		owner,
+		description,
+		created_at,
+		updated_at
+	from playlists
+	where id = $1;
+	`
+
+	details := &mplaylist.Details{
+		Items: []mplaylist.Item{},
+	}
+
+	if err := pr.store.QueryRow(
+		ctx,
+		sql,
+		id,
+	).Scan(
+		&details.ID,
+		&details.Name,
+		&details.Owner,
+		&details.Description,
+		&details.CreatedAt,
+		&details.UpdatedAt,
+	); err != nil {
+		return nil, mapError(err)
+	}
+
+	const itemsSQL = `
+	select
+		playlist_items.id,
+		row_number() over (order by playlist_items.position),
+		playlist_items.added_at,
+		s.id,
+		s."group",
+		s.song,
+		coalesce(to_char(s.release_date, 'DD.MM.YYYY'), ''),
+		s.link
+	from playlist_items
+	join song_listing s on s.id = playlist_items.song_id
+	where playlist_items.playlist_id = $1
+	order by playlist_items.position;
+	`
+
+	rows, err := pr.store.Query(ctx, itemsSQL, id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := mplaylist.Item{}
+		if err := rows.Scan(
+			&item.ID,
+			&item.Position,
+			&item.AddedAt,
+			&item.Song.ID,
+			&item.Song.Group,
+			&item.Song.Song,
+			&item.Song.ReleaseDate,
+			&item.Song.Link,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		details.Items = append(details.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	details.ItemCount = len(details.Items)
+
+	return details, nil
+}
+
+func (pr *PlaylistRepository) Create(ctx context.Context, playlist mplaylist.Playlist) (uint64, error) {
+	const sql = `
+	insert into playlists(
+		name,
+		owner,
+		description
+	) values ($1, $2, $3)
+	returning id;
+	`
+
+	var id uint64
+	if err := pr.store.QueryRow(
+		ctx,
+		sql,
+		playlist.Name,
+		playlist.Owner,
+		playlist.Description,
+	).Scan(
+		&id,
+	); err != nil {
+		return 0, mapError(err)
+	}
+
+	return id, nil
+}
+
+func (pr *PlaylistRepository) Update(ctx context.Context, playlist mplaylist.Playlist) error {
+	const sql = `
+	update
+		playlists
+	set
+		name = $1,
+		owner = $2,
+		description = $3,
+		updated_at = now()
+	where id = $4;
+	`
+
+	tag, err := pr.store.Exec(
+		ctx,
+		sql,
+		playlist.Name,
+		playlist.Owner,
+		playlist.Description,
+		playlist.ID,
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return mplaylist.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the playlist with its items, the songs are kept.
+func (pr *PlaylistRepository) Delete(ctx context.Context, id uint64) error {
+	const sql = `
+	delete from playlists
+	where id = $1;
+	`
+
+	tag, err := pr.store.Exec(ctx, sql, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return mplaylist.ErrNotFound
+	}
+
+	return nil
+}
+
+// AddItem puts the song on the playlist at placement and returns the new item ID.
+func (pr *PlaylistRepository) AddItem(
+	ctx context.Context,
+	id, songID uint64,
+	placement mplaylist.Placement,
+) (uint64, error) {
+	var itemID uint64
+
+	err := pr.edit(ctx, id, func(tx pgx.Tx) error {
+		position, err := placePosition(ctx, tx, id, 0, placement)
+		if err != nil {
+			return err
+		}
+
+		const sql = `
+		insert into playlist_items(
+			playlist_id,
+			song_id,
+			position
+		)
+		select $1, $2, $3
+		where exists (select 1 from songs where id = $2)
+		returning id;
+		`
+
+		if err := tx.QueryRow(ctx, sql, id, songID, position).Scan(&itemID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return msong.ErrNotFound
+			}
+
+			return mapError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return itemID, nil
+}
+
+// MoveItem moves the item to placement. Only the moved item changes its
+// position.
+func (pr *PlaylistRepository) MoveItem(
+	ctx context.Context,
+	id, itemID uint64,
+	placement mplaylist.Placement,
+) error {
+	return pr.edit(ctx, id, func(tx pgx.Tx) error {
+		position, err := placePosition(ctx, tx, id, itemID, placement)
+		if err != nil {
+			return err
+		}
+
+		const sql = `
+		update
+			playlist_items
+		set
+			position = $1
+		where id = $2 and playlist_id = $3;
+		`
+
+		tag, err := tx.Exec(ctx, sql, position, itemID, id)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return mplaylist.ErrItemNotFound
+		}
+
+		return nil
+	})
+}
+
+func (pr *PlaylistRepository) RemoveItem(ctx context.Context, id, itemID uint64) error {
+	return pr.edit(ctx, id, func(tx pgx.Tx) error {
+		const sql = `
+		delete from playlist_items
+		where id = $1 and playlist_id = $2;
+		`
+
+		tag, err := tx.Exec(ctx, sql, itemID, id)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return mplaylist.ErrItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// edit runs fn in a transaction holding the playlist row lock, so item
+// positions are computed against a stable list, and marks the playlist
+// as changed.
+func (pr *PlaylistRepository) edit(ctx context.Context, id uint64, fn func(tx pgx.Tx) error) error {
+	return pgx.BeginFunc(ctx, pr.store, func(tx pgx.Tx) error {
+		const sql = `
+		update
+			playlists
+		set
+			updated_at = now()
+		where id = $1;
+		`
+
+		tag, err := tx.Exec(ctx, sql, id)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return mplaylist.ErrNotFound
+		}
+
+		return fn(tx)
+	})
+}
+
+// placePosition returns a free position for an item at placement, skipping
+// the item being moved. Between two neighbours it is their midpoint. When
+// the midpoint can no longer be told apart from a neighbour the playlist is
+// renumbered once and the position computed again.
+func placePosition(
+	ctx context.Context,
+	tx pgx.Tx,
+	id, movingID uint64,
+	placement mplaylist.Placement,
+) (pgtype.Numeric, error) {
+	sql := appendSQL
+	args := []any{id, movingID}
+
+	switch {
+	case placement.Before != 0:
+		sql, args = beforeSQL, append(args, placement.Before)
+	case placement.After != 0:
+		sql, args = afterSQL, append(args, placement.After)
+	}
+
+	for attempt := 0; ; attempt++ {
+		var (
+			position pgtype.Numeric
+			free     bool
+		)
+
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&position, &free); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pgtype.Numeric{}, mplaylist.ErrItemNotFound
+			}
+
+			return pgtype.Numeric{}, mapError(err)
+		}
+
+		if free || attempt > 0 {
+			return position, nil
+		}
+
+		if _, err := tx.Exec(ctx, renumberSQL, id); err != nil {
+			return pgtype.Numeric{}, mapError(err)
+		}
+	}
+}
+
+// The position queries take the playlist ID, the ID of the item being
+// moved (0 for a new one) and the ID of the neighbour to place it at.
+// Besides the position they tell whether it falls strictly between the
+// neighbours.
+const (
+	appendSQL = `
+	select
+		coalesce(max(position), 0) + 1,
+		true
+	from playlist_items
+	where playlist_id = $1 and id <> $2;
+	`
+
+	beforeSQL = `
+	with target as (
+		select position from playlist_items where id = $3 and playlist_id = $1
+	), previous as (
+		select max(position) as position
+		from playlist_items
+		where playlist_id = $1 and id <> $2 and position < (select position from target)
+	), placed as (
+		select
+			previous.position as previous,
+			target.position as target,
+			(previous.position + target.position) / 2 as midpoint
+		from target, previous
+	)
+	select
+		coalesce(midpoint, target - 1),
+		previous is null or (midpoint > previous and midpoint < target)
+	from placed;
+	`
+
+	afterSQL = `
+	with target as (
+		select position from playlist_items where id = $3 and playlist_id = $1
+	), following as (
+		select min(position) as position
+		from playlist_items
+		where playlist_id = $1 and id <> $2 and position > (select position from target)
+	), placed as (
+		select
+			target.position as target,
+			following.position as following,
+			(target.position + following.position) / 2 as midpoint
+		from target, following
+	)
+	select
+		coalesce(midpoint, target + 1),
+		following is null or (midpoint > target and midpoint < following)
+	from placed;
+	`
+
+	renumberSQL = `
+	update
+		playlist_items
+	set
+		position = numbered.n
+	from (
+		select id, row_number() over (order by position) as n
+		from playlist_items
+		where playlist_id = $1
+	) as numbered
+	where playlist_items.id = numbered.id;
+	`
+)
+
+// mapError translates driver errors into domain errors.
+func mapError(err error) error {
+	return pgerror.Map(err, mplaylist.ErrNotFound)
+}
@@ -0,0 +1,277 @@
+package playlistrepository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	mplaylist "online-song-library/internal/model/playlist"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/pgtest"
+	"online-song-library/internal/repository/playlistrepository"
+	"online-song-library/internal/repository/songrepository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type fixture struct {
+	pool      *pgxpool.Pool
+	playlists *playlistrepository.PlaylistRepository
+	songs     *songrepository.SongRepository
+	id        uint64
+	// items and songIDs are keyed by the label of the item, which is also
+	// the title of its song.
+	items   map[string]uint64
+	songIDs map[string]uint64
+}
+
+// newFixture stores a playlist with an item for each label, in order, over
+// an empty test database.
+func newFixture(t *testing.T, labels ...string) *fixture {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	f := &fixture{
+		pool:      pool,
+		playlists: playlistrepository.NewPlaylistRepository(pool),
+		songs:     songrepository.NewSongRepository(pool),
+		items:     map[string]uint64{},
+		songIDs:   map[string]uint64{},
+	}
+
+	var err error
+
+	playlist := mplaylist.Playlist{Name: "Road trip", Owner: "ann"}
+	if f.id, err = f.playlists.Create(context.Background(), playlist); err != nil {
+		t.Fatalf("Create(playlist) error = %v", err)
+	}
+
+	for _, label := range labels {
+		f.add(t, label, mplaylist.Placement{})
+	}
+
+	return f
+}
+
+// add stores a song titled label and puts it on the playlist at placement.
+func (f *fixture) add(t *testing.T, label string, placement mplaylist.Placement) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	songID, err := f.songs.Create(ctx, msong.Song{Group: "Muse", Song: label, Verses: []string{}})
+	if err != nil {
+		t.Fatalf("Create(%s) error = %v", label, err)
+	}
+
+	itemID, err := f.playlists.AddItem(ctx, f.id, songID, placement)
+	if err != nil {
+		t.Fatalf("AddItem(%s, %+v) error = %v", label, placement, err)
+	}
+
+	f.songIDs[label] = songID
+	f.items[label] = itemID
+}
+
+// order returns the labels of the playlist items in order and checks their
+// positions are numbered from 1.
+func (f *fixture) order(t *testing.T) []string {
+	t.Helper()
+
+	details, err := f.playlists.GetByID(context.Background(), f.id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	labels := make([]string, 0, len(details.Items))
+
+	for i, item := range details.Items {
+		if item.Position != i+1 {
+			t.Errorf("item %d position = %d, want %d", item.ID, item.Position, i+1)
+		}
+
+		labels = append(labels, item.Song.Song)
+	}
+
+	if details.ItemCount != len(labels) {
+		t.Errorf("ItemCount = %d, want %d", details.ItemCount, len(labels))
+	}
+
+	return labels
+}
+
+var placementTests = []struct {
+	name string
+	// move is the label of the item moved, a new item D is added if empty.
+	move          string
+	before, after string
+	want          []string
+}{
+	{name: "add at the end", want: []string{"A", "B", "C", "D"}},
+	{name: "add before the head", before: "A", want: []string{"D", "A", "B", "C"}},
+	{name: "add after the head", after: "A", want: []string{"A", "D", "B", "C"}},
+	{name: "add before the middle", before: "B", want: []string{"A", "D", "B", "C"}},
+	{name: "add after the middle", after: "B", want: []string{"A", "B", "D", "C"}},
+	{name: "add before the tail", before: "C", want: []string{"A", "B", "D", "C"}},
+	{name: "add after the tail", after: "C", want: []string{"A", "B", "C", "D"}},
+	{name: "move to the end", move: "A", want: []string{"B", "C", "A"}},
+	{name: "move the tail before the head", move: "C", before: "A", want: []string{"C", "A", "B"}},
+	{name: "move the head after the tail", move: "A", after: "C", want: []string{"B", "C", "A"}},
+	{name: "move the head after the middle", move: "A", after: "B", want: []string{"B", "A", "C"}},
+	{name: "move the tail before the middle", move: "C", before: "B", want: []string{"A", "C", "B"}},
+	{name: "move before its follower", move: "A", before: "B", want: []string{"A", "B", "C"}},
+	{name: "move before itself", move: "B", before: "B", want: []string{"A", "B", "C"}},
+	{name: "move after itself", move: "B", after: "B", want: []string{"A", "B", "C"}},
+	{name: "move the tail after itself", move: "C", after: "C", want: []string{"A", "B", "C"}},
+}
+
+func TestPlacement(t *testing.T) {
+	for _, tt := range placementTests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, "A", "B", "C")
+			placement := mplaylist.Placement{Before: f.items[tt.before], After: f.items[tt.after]}
+
+			if tt.move == "" {
+				f.add(t, "D", placement)
+			} else if err := f.playlists.MoveItem(context.Background(), f.id, f.items[tt.move], placement); err != nil {
+				t.Fatalf("MoveItem(%s, %+v) error = %v", tt.move, placement, err)
+			}
+
+			if got := f.order(t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlacementUnknownNeighbour(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t, "A", "B")
+
+	other, err := f.playlists.Create(ctx, mplaylist.Playlist{Name: "Other", Owner: "bob"})
+	if err != nil {
+		t.Fatalf("Create(other playlist) error = %v", err)
+	}
+
+	foreign, err := f.playlists.AddItem(ctx, other, f.songIDs["A"], mplaylist.Placement{})
+	if err != nil {
+		t.Fatalf("AddItem(other playlist) error = %v", err)
+	}
+
+	for _, placement := range []mplaylist.Placement{
+		{Before: 1 << 30},
+		{After: 1 << 30},
+		{Before: foreign},
+		{After: foreign},
+	} {
+		if _, err := f.playlists.AddItem(ctx, f.id, f.songIDs["A"], placement); !errors.Is(err, mplaylist.ErrItemNotFound) {
+			t.Errorf("AddItem(%+v) error = %v, want %v", placement, err, mplaylist.ErrItemNotFound)
+		}
+
+		if err := f.playlists.MoveItem(ctx, f.id, f.items["A"], placement); !errors.Is(err, mplaylist.ErrItemNotFound) {
+			t.Errorf("MoveItem(%+v) error = %v, want %v", placement, err, mplaylist.ErrItemNotFound)
+		}
+	}
+
+	if got, want := f.order(t), []string{"A", "B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+// TestPlacementRenumbers keeps inserting right before the same item, halving
+// the gap each time, until midpoints run out of precision and the playlist
+// has to be renumbered.
+func TestPlacementRenumbers(t *testing.T) {
+	const inserts = 100
+
+	f := newFixture(t, "A", "B")
+	want := []string{"A"}
+
+	for i := range inserts {
+		label := fmt.Sprintf("x%03d", i)
+		f.add(t, label, mplaylist.Placement{Before: f.items["B"]})
+		want = append(want, label)
+	}
+
+	want = append(want, "B")
+
+	if got := f.order(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	const sql = `
+	select count(*)
+	from playlist_items
+	where playlist_id = $1 and position = trunc(position);
+	`
+
+	var whole int
+	if err := f.pool.QueryRow(context.Background(), sql, f.id).Scan(&whole); err != nil {
+		t.Fatalf("count whole positions: %v", err)
+	}
+
+	// Without renumbering only A and B keep whole positions.
+	if whole <= 2 {
+		t.Errorf("whole positions = %d, want the playlist renumbered", whole)
+	}
+}
+
+func TestDeleteSongRemovesItems(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t, "A", "B", "C")
+
+	if err := f.songs.Delete(ctx, msong.Song{ID: f.songIDs["B"]}); err != nil {
+		t.Fatalf("Delete(B) error = %v", err)
+	}
+
+	if got, want := f.order(t), []string{"A", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	if err := f.playlists.MoveItem(ctx, f.id, f.items["A"], mplaylist.Placement{After: f.items["B"]}); !errors.Is(
+		err, mplaylist.ErrItemNotFound,
+	) {
+		t.Errorf("MoveItem(after deleted item) error = %v, want %v", err, mplaylist.ErrItemNotFound)
+	}
+}
+
+func TestListTotal(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	for _, owner := range []string{"ann", "bob"} {
+		if _, err := f.playlists.Create(ctx, mplaylist.Playlist{Name: "Favourites", Owner: owner}); err != nil {
+			t.Fatalf("Create(%s) error = %v", owner, err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		owner         string
+		offset, limit int
+		wantPlaylists int
+		wantTotal     int
+	}{
+		{name: "first page", offset: 1, limit: 2, wantPlaylists: 2, wantTotal: 3},
+		{name: "last page", offset: 3, limit: 2, wantPlaylists: 1, wantTotal: 3},
+		{name: "past the end", offset: 10, limit: 2, wantTotal: 3},
+		{name: "owner past the end", owner: "ann", offset: 5, limit: 2, wantTotal: 2},
+		{name: "unknown owner", owner: "eve", offset: 1, limit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := f.playlists.List(ctx, tt.owner, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(page.Playlists) != tt.wantPlaylists || page.Total != tt.wantTotal {
+				t.Errorf("List() = %d playlists of %d, want %d of %d",
+					len(page.Playlists), page.Total, tt.wantPlaylists, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package songinforepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SongInfoRepository is a music info cache kept in Postgres, so it is shared
+// by all instances and survives restarts.
+type SongInfoRepository struct {
+	store dbstore.Store
+}
+
+func NewSongInfoRepository(store dbstore.Store) *SongInfoRepository {
+	return &SongInfoRepository{
+		store: store,
+	}
+}
+
+func (sir *SongInfoRepository) Get(ctx context.Context, key string) (infoservice.CacheEntry, bool, error) {
+	const sql = `
+	select
+		release_date,
+		text,
+		link,
+		not_found
+	from song_info_cache
+	where key = $1 and expires_at > now();
+	`
+
+	entry := infoservice.CacheEntry{}
+	if err := sir.store.QueryRow(ctx, sql, key).Scan(
+		&entry.Detail.ReleaseDate,
+		&entry.Detail.Text,
+		&entry.Detail.Link,
+		&entry.NotFound,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return infoservice.CacheEntry{}, false, nil
+		}
+
+		return infoservice.CacheEntry{}, false, err
+	}
+
+	return entry, true, nil
+}
+
+// Set stores the entry and drops expired ones along the way.
+func (sir *SongInfoRepository) Set(
+	ctx context.Context,
+	key string,
+	entry infoservice.CacheEntry,
+	ttl time.Duration,
+) error {
+	const sql = `
+	with expired as (
+		delete from song_info_cache
+		where expires_at <= now() and key <> $1
+	)
+	insert into song_info_cache(
+		key,
+		release_date,
+		text,
+		link,
+		not_found,
+		expires_at
+	) values ($1, $2, $3, $4, $5, $6)
+	on conflict (key) do update
+	set
+		release_date = excluded.release_date,
+		text = excluded.text,
+		link = excluded.link,
+		not_found = excluded.not_found,
+		expires_at = excluded.expires_at;
+	`
+
+	_, err := sir.store.Exec(
+		ctx,
+		sql,
+		key,
+		entry.Detail.ReleaseDate,
+		entry.Detail.Text,
+		entry.Detail.Link,
+		entry.NotFound,
+		time.Now().Add(ttl),
+	)
+
+	return err
+}
@@ -0,0 +1,101 @@
+package songinforepository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/pgtest"
+	"online-song-library/internal/repository/songinforepository"
+)
+
+var detail = infoservice.CacheEntry{
+	Detail: infoservice.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?",
+		Link:        "https://example.com/smbh",
+	},
+}
+
+func TestSetAndGet(t *testing.T) {
+	ctx := context.Background()
+	repo := songinforepository.NewSongInfoRepository(pgtest.New(t))
+
+	if _, ok, err := repo.Get(ctx, "muse\x00hysteria"); err != nil || ok {
+		t.Fatalf("Get(missing) = %t, %v, want a miss", ok, err)
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		entry infoservice.CacheEntry
+	}{
+		{name: "detail", key: "muse\x00supermassive black hole", entry: detail},
+		{name: "not found", key: "muse\x00unknown", entry: infoservice.CacheEntry{NotFound: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repo.Set(ctx, tt.key, tt.entry, time.Hour); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+
+			got, ok, err := repo.Get(ctx, tt.key)
+			if err != nil || !ok {
+				t.Fatalf("Get() = %t, %v, want a hit", ok, err)
+			}
+
+			if got != tt.entry {
+				t.Errorf("Get() = %+v, want %+v", got, tt.entry)
+			}
+		})
+	}
+}
+
+func TestSetReplaces(t *testing.T) {
+	ctx := context.Background()
+	repo := songinforepository.NewSongInfoRepository(pgtest.New(t))
+
+	const key = "muse\x00supermassive black hole"
+
+	if err := repo.Set(ctx, key, infoservice.CacheEntry{NotFound: true}, time.Hour); err != nil {
+		t.Fatalf("Set(not found) error = %v", err)
+	}
+
+	if err := repo.Set(ctx, key, detail, time.Hour); err != nil {
+		t.Fatalf("Set(detail) error = %v", err)
+	}
+
+	if got, ok, err := repo.Get(ctx, key); err != nil || !ok || got != detail {
+		t.Errorf("Get() = %+v, %t, %v, want %+v", got, ok, err, detail)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	ctx := context.Background()
+	pool := pgtest.New(t)
+	repo := songinforepository.NewSongInfoRepository(pool)
+
+	if err := repo.Set(ctx, "expired", detail, -time.Second); err != nil {
+		t.Fatalf("Set(expired) error = %v", err)
+	}
+
+	if _, ok, err := repo.Get(ctx, "expired"); err != nil || ok {
+		t.Errorf("Get(expired) = %t, %v, want a miss", ok, err)
+	}
+
+	// Storing another entry drops the expired one.
+	if err := repo.Set(ctx, "fresh", detail, time.Hour); err != nil {
+		t.Fatalf("Set(fresh) error = %v", err)
+	}
+
+	var keys int
+	if err := pool.QueryRow(ctx, "select count(*) from song_info_cache;").Scan(&keys); err != nil {
+		t.Fatalf("count entries: %v", err)
+	}
+
+	if keys != 1 {
+		t.Errorf("entries = %d, want only the fresh one", keys)
+	}
+}
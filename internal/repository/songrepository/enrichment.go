@@ -0,0 +1,242 @@
+package songrepository
+
+import (
+	"context"
+	"time"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RequeueEnrichment marks the song pending and schedules its enrichment
+// right away.
+func (sr *SongRepository) RequeueEnrichment(ctx context.Context, id uint64) error {
+	queued, err := sr.requeueEnrichment(ctx, "id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	if queued == 0 {
+		return msong.ErrNotFound
+	}
+
+	return nil
+}
+
+// RequeueAllEnrichment does RequeueEnrichment for every song, or only for the
+// songs in the given enrichment status if it is not empty. It returns the
+// number of queued songs.
+func (sr *SongRepository) RequeueAllEnrichment(ctx context.Context, status string) (int, error) {
+	return sr.requeueEnrichment(ctx, "($1 = '' or enrichment_status = $1)", status)
+}
+
+func (sr *SongRepository) requeueEnrichment(ctx context.Context, where string, arg any) (int, error) {
+	sql := `
+	with requeued as (
+		update
+			songs
+		set
+			enrichment_status = 'pending',
+			updated_at = now(),
+			version = version + 1
+		where ` + where + `
+		returning id
+	)
+	insert into enrichment_jobs(
+		song_id
+	)
+	select id from requeued
+	on conflict (song_id) do update
+	set
+		attempts = 0,
+		run_at = now(),
+		last_error = null;
+	`
+
+	tag, err := sr.store.Exec(ctx, sql, arg)
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// enqueueEnrichment schedules an enrichment job for the song right away,
+// resetting the attempts of an already queued one.
+func enqueueEnrichment(ctx context.Context, store dbstore.Store, songID uint64) error {
+	const sql = `
+	insert into enrichment_jobs(
+		song_id
+	) values ($1)
+	on conflict (song_id) do update
+	set
+		attempts = 0,
+		run_at = now(),
+		last_error = null;
+	`
+
+	if _, err := store.Exec(ctx, sql, songID); err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// ClaimEnrichmentJobs picks up to limit due jobs and leases them: their
+// run_at moves lease into the future, so other workers skip them while they
+// are processed and pick them up again if this worker dies. Rows locked by
+// concurrent claims are skipped.
+func (sr *SongRepository) ClaimEnrichmentJobs(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]msong.EnrichmentJob, error) {
+	const sql = `
+	with due as (
+		select
+			id
+		from enrichment_jobs
+		where run_at <= now()
+		order by run_at
+		limit $1
+		for update skip locked
+	)
+	update
+		enrichment_jobs j
+	set
+		attempts = j.attempts + 1,
+		run_at = now() + $2 * interval '1 millisecond'
+	from due, songs s
+	where j.id = due.id and s.id = j.song_id
+	returning
+		j.id,
+		j.song_id,
+		j.attempts,
+		s."group",
+		s.song;
+	`
+
+	rows, err := sr.store.Query(ctx, sql, limit, float64(lease.Milliseconds()))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	jobs := []msong.EnrichmentJob{}
+	for rows.Next() {
+		job := msong.EnrichmentJob{}
+		if err := rows.Scan(
+			&job.ID,
+			&job.Song.ID,
+			&job.Attempts,
+			&job.Song.Group,
+			&job.Song.Song,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	return jobs, nil
+}
+
+// CompleteEnrichmentJob fills in the details the song is missing and removes
+// the job. Details the song already has are kept.
+func (sr *SongRepository) CompleteEnrichmentJob(ctx context.Context, job msong.EnrichmentJob, song msong.Song) error {
+	releaseDate, err := releaseDateArg(song.ReleaseDate)
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const sql = `
+		update
+			songs
+		set
+			release_date = coalesce(release_date, $1),
+			verses = case when cardinality(verses) = 0 then $2 else verses end,
+			link = coalesce(nullif(link, ''), $3),
+			enrichment_status = 'ready',
+			updated_at = now(),
+			version = version + 1
+		where id = $4;
+		`
+
+		if _, err := tx.Exec(
+			ctx,
+			sql,
+			releaseDate,
+			song.Verses,
+			song.Link,
+			job.Song.ID,
+		); err != nil {
+			return mapError(err)
+		}
+
+		return deleteEnrichmentJob(ctx, tx, job)
+	})
+}
+
+// RetryEnrichmentJob reschedules the job after a failed attempt.
+func (sr *SongRepository) RetryEnrichmentJob(
+	ctx context.Context,
+	job msong.EnrichmentJob,
+	runAt time.Time,
+	cause error,
+) error {
+	const sql = `
+	update
+		enrichment_jobs
+	set
+		run_at = $1,
+		last_error = $2
+	where id = $3;
+	`
+
+	if _, err := sr.store.Exec(ctx, sql, runAt, cause.Error(), job.ID); err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// FailEnrichmentJob gives up on the job and marks the song as failed.
+func (sr *SongRepository) FailEnrichmentJob(ctx context.Context, job msong.EnrichmentJob) error {
+	return pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const sql = `
+		update
+			songs
+		set
+			enrichment_status = 'failed',
+			updated_at = now(),
+			version = version + 1
+		where id = $1;
+		`
+
+		if _, err := tx.Exec(ctx, sql, job.Song.ID); err != nil {
+			return mapError(err)
+		}
+
+		return deleteEnrichmentJob(ctx, tx, job)
+	})
+}
+
+func deleteEnrichmentJob(ctx context.Context, store dbstore.Store, job msong.EnrichmentJob) error {
+	const sql = `
+	delete from enrichment_jobs
+	where id = $1;
+	`
+
+	if _, err := store.Exec(ctx, sql, job.ID); err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
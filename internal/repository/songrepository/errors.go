@@ -0,0 +1,11 @@
+package songrepository
+
+import (
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/pgerror"
+)
+
+// mapError translates driver errors into domain errors.
+func mapError(err error) error {
+	return pgerror.Map(err, msong.ErrNotFound)
+}
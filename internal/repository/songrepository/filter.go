@@ -0,0 +1,148 @@
+package songrepository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+)
+
+// songColumns whitelists API field names that may be used in filters and sorts.
+var songColumns = map[string]string{
+	"group":       `"group"`,
+	"song":        "song",
+	"releaseDate": "release_date",
+	"link":        "link",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// queryArgs collects positional arguments and hands out their placeholders.
+type queryArgs []any
+
+func (qa *queryArgs) bind(value any) string {
+	*qa = append(*qa, value)
+
+	return "$" + strconv.Itoa(len(*qa))
+}
+
+func buildWhere(filter msong.Filter, args *queryArgs) (string, error) {
+	if len(filter) == 0 {
+		return "true", nil
+	}
+
+	predicates := make([]string, 0, len(filter))
+	for _, cond := range filter {
+		predicate, err := buildPredicate(cond, args)
+		if err != nil {
+			return "", err
+		}
+
+		predicates = append(predicates, predicate)
+	}
+
+	return strings.Join(predicates, " and "), nil
+}
+
+func buildPredicate(cond msong.Condition, args *queryArgs) (string, error) {
+	// artistId and tags can only be filtered on, not sorted by, so they are
+	// not song columns.
+	if cond.Field == "artistId" {
+		return buildArtistPredicate(cond, args)
+	}
+
+	if cond.Field == "tags" {
+		return buildTagsPredicate(cond, args)
+	}
+
+	column, ok := songColumns[cond.Field]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown field %q", msong.ErrInvalidFilter, cond.Field)
+	}
+
+	if column == "release_date" {
+		return buildDatePredicate(column, cond, args)
+	}
+
+	switch cond.Op {
+	case msong.OpEq:
+		return fmt.Sprintf("%s = %s", column, args.bind(cond.Value)), nil
+	case msong.OpIlike:
+		return fmt.Sprintf("%s ilike %s", column, args.bind(cond.Value)), nil
+	case msong.OpContains:
+		return fmt.Sprintf("%s ilike %s", column, args.bind("%"+likeEscaper.Replace(cond.Value)+"%")), nil
+	case msong.OpPrefix:
+		return fmt.Sprintf("%s ilike %s", column, args.bind(likeEscaper.Replace(cond.Value)+"%")), nil
+	case msong.OpFrom, msong.OpTo:
+	}
+
+	return "", fmt.Errorf("%w: operator %q is not supported for %q", msong.ErrInvalidFilter, cond.Op, cond.Field)
+}
+
+func buildArtistPredicate(cond msong.Condition, args *queryArgs) (string, error) {
+	if cond.Op != msong.OpEq {
+		return "", fmt.Errorf("%w: operator %q is not supported for %q", msong.ErrInvalidFilter, cond.Op, cond.Field)
+	}
+
+	id, err := strconv.ParseUint(cond.Value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s must be an artist ID", msong.ErrInvalidFilter, cond.Field)
+	}
+
+	return fmt.Sprintf("artist_id = %s", args.bind(id)), nil
+}
+
+// buildTagsPredicate matches songs with any of the listed tags, or with all
+// of them for OpAll. Tags are compared case-insensitively.
+func buildTagsPredicate(cond msong.Condition, args *queryArgs) (string, error) {
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, tag := range msong.SplitTags(cond.Value) {
+		if name := strings.ToLower(tag); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "", fmt.Errorf("%w: %s must list at least one tag", msong.ErrInvalidFilter, cond.Field)
+	}
+
+	const tagged = `
+		select song_tags.song_id
+		from song_tags
+		join tags on tags.id = song_tags.tag_id
+		where lower(tags.name) = any(%s)`
+
+	switch cond.Op {
+	case msong.OpEq, msong.OpAny:
+		return fmt.Sprintf("id in ("+tagged+")", args.bind(names)), nil
+	case msong.OpAll:
+		return fmt.Sprintf("id in ("+tagged+`
+		group by song_tags.song_id
+		having count(*) = %s)`, args.bind(names), args.bind(len(names))), nil
+	}
+
+	return "", fmt.Errorf("%w: operator %q is not supported for %q", msong.ErrInvalidFilter, cond.Op, cond.Field)
+}
+
+func buildDatePredicate(column string, cond msong.Condition, args *queryArgs) (string, error) {
+	date, err := msong.ParseReleaseDate(cond.Value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", msong.ErrInvalidFilter, cond.Field, err)
+	}
+
+	switch cond.Op {
+	case msong.OpEq:
+		return fmt.Sprintf("%s::date = %s", column, args.bind(date)), nil
+	case msong.OpFrom:
+		return fmt.Sprintf("%s::date >= %s", column, args.bind(date)), nil
+	case msong.OpTo:
+		return fmt.Sprintf("%s::date <= %s", column, args.bind(date)), nil
+	case msong.OpIlike, msong.OpContains, msong.OpPrefix:
+	}
+
+	return "", fmt.Errorf("%w: operator %q is not supported for %q", msong.ErrInvalidFilter, cond.Op, cond.Field)
+}
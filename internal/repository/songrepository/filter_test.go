@@ -0,0 +1,284 @@
+package songrepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/songrepository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// fakeStore records the last query it was asked to run. It answers every
+// QueryRow with a count of zero and fails every Query.
+type fakeStore struct {
+	sql   string
+	args  []any
+	calls int
+}
+
+func (fs *fakeStore) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errNotImplemented
+}
+
+func (fs *fakeStore) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	fs.sql = sql
+	fs.args = args
+	fs.calls++
+
+	return nil, errNotImplemented
+}
+
+func (fs *fakeStore) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	fs.sql = sql
+	fs.args = args
+	fs.calls++
+
+	return countRow{}
+}
+
+func (fs *fakeStore) Begin(context.Context) (pgx.Tx, error) {
+	return nil, errNotImplemented
+}
+
+func (fs *fakeStore) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	return nil, errNotImplemented
+}
+
+type countRow struct{}
+
+func (countRow) Scan(dest ...any) error {
+	*dest[0].(*int) = 0
+
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// filterTest is a filter and the where clause and arguments CountSongs
+// must build for it.
+type filterTest struct {
+	name      string
+	filter    msong.Filter
+	wantWhere string
+	wantArgs  []any
+}
+
+func runFilterTests(t *testing.T, tests []filterTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{}
+
+			if _, err := songrepository.NewSongRepository(store).CountSongs(context.Background(), tt.filter); err != nil {
+				t.Fatalf("CountSongs() error = %v", err)
+			}
+
+			sql := strings.Join(strings.Fields(store.sql), " ")
+			if want := "where " + tt.wantWhere + ";"; !strings.Contains(sql, want) {
+				t.Errorf("CountSongs() sql = %q, want it to contain %q", sql, want)
+			}
+
+			if len(store.args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(store.args, tt.wantArgs) {
+					t.Errorf("CountSongs() args = %#v, want %#v", store.args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+// invalidFilterTest is a filter CountSongs must reject without running
+// a query.
+type invalidFilterTest struct {
+	name   string
+	filter msong.Filter
+}
+
+func runInvalidFilterTests(t *testing.T, tests []invalidFilterTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{}
+
+			_, err := songrepository.NewSongRepository(store).CountSongs(context.Background(), tt.filter)
+			if !errors.Is(err, msong.ErrInvalidFilter) {
+				t.Errorf("CountSongs() error = %v, want %v", err, msong.ErrInvalidFilter)
+			}
+
+			if store.calls != 0 {
+				t.Errorf("CountSongs() ran a query for an invalid filter: %q", store.sql)
+			}
+		})
+	}
+}
+
+var filterTests = []filterTest{
+	{
+		name:      "no filter",
+		filter:    msong.Filter{},
+		wantWhere: "true",
+	},
+	{
+		name:      "eq",
+		filter:    msong.Filter{}.And("group", msong.OpEq, "Muse"),
+		wantWhere: `"group" = $1`,
+		wantArgs:  []any{"Muse"},
+	},
+	{
+		name:      "ilike keeps the pattern as is",
+		filter:    msong.Filter{}.And("song", msong.OpIlike, "Hyst%_"),
+		wantWhere: "song ilike $1",
+		wantArgs:  []any{"Hyst%_"},
+	},
+	{
+		name:      "contains escapes like wildcards",
+		filter:    msong.Filter{}.And("link", msong.OpContains, `50%_off\x`),
+		wantWhere: "link ilike $1",
+		wantArgs:  []any{`%50\%\_off\\x%`},
+	},
+	{
+		name:      "prefix escapes like wildcards",
+		filter:    msong.Filter{}.And("group", msong.OpPrefix, "The_"),
+		wantWhere: `"group" ilike $1`,
+		wantArgs:  []any{`The\_%`},
+	},
+	{
+		name:      "release date in DD.MM.YYYY",
+		filter:    msong.Filter{}.And("releaseDate", msong.OpEq, "16.07.2006"),
+		wantWhere: "release_date::date = $1",
+		wantArgs:  []any{date(2006, time.July, 16)},
+	},
+	{
+		name: "release date range in ISO 8601",
+		filter: msong.Filter{}.
+			And("releaseDate", msong.OpFrom, "2000-01-01").
+			And("releaseDate", msong.OpTo, "2009-12-31"),
+		wantWhere: "release_date::date >= $1 and release_date::date <= $2",
+		wantArgs:  []any{date(2000, time.January, 1), date(2009, time.December, 31)},
+	},
+	{
+		name: "conditions are joined with and",
+		filter: msong.Filter{}.
+			And("group", msong.OpEq, "Muse").
+			And("song", msong.OpContains, "hyst"),
+		wantWhere: `"group" = $1 and song ilike $2`,
+		wantArgs:  []any{"Muse", "%hyst%"},
+	},
+}
+
+func TestCountSongsFilter(t *testing.T) {
+	runFilterTests(t, filterTests)
+}
+
+var invalidFilterTests = []invalidFilterTest{
+	{
+		name:   "field outside the whitelist",
+		filter: msong.Filter{}.And("verses", msong.OpEq, "x"),
+	},
+	{
+		name:   "column name injection",
+		filter: msong.Filter{}.And(`song; drop table songs; --`, msong.OpEq, "x"),
+	},
+	{
+		name:   "unknown operator",
+		filter: msong.Filter{}.And("group", msong.Operator("regex"), "x"),
+	},
+	{
+		name:   "date operator on a text field",
+		filter: msong.Filter{}.And("group", msong.OpFrom, "2000-01-01"),
+	},
+	{
+		name:   "pattern operator on the release date",
+		filter: msong.Filter{}.And("releaseDate", msong.OpContains, "2006"),
+	},
+	{
+		name:   "malformed release date",
+		filter: msong.Filter{}.And("releaseDate", msong.OpTo, "2006/07/16"),
+	},
+	{
+		name:   "valid condition followed by an invalid one",
+		filter: msong.Filter{}.And("group", msong.OpEq, "Muse").And("link", msong.OpTo, "x"),
+	},
+}
+
+func TestCountSongsInvalidFilter(t *testing.T) {
+	runInvalidFilterTests(t, invalidFilterTests)
+}
+
+func TestCountSongsArtistFilter(t *testing.T) {
+	runFilterTests(t, []filterTest{
+		{
+			name:      "artist ID",
+			filter:    msong.Filter{}.And("artistId", msong.OpEq, "7"),
+			wantWhere: "artist_id = $1",
+			wantArgs:  []any{uint64(7)},
+		},
+		{
+			name:      "artist ID with another condition",
+			filter:    msong.Filter{}.And("artistId", msong.OpEq, "7").And("song", msong.OpPrefix, "hyst"),
+			wantWhere: "artist_id = $1 and song ilike $2",
+			wantArgs:  []any{uint64(7), "hyst%"},
+		},
+	})
+
+	runInvalidFilterTests(t, []invalidFilterTest{
+		{
+			name:   "artist ID that is not a number",
+			filter: msong.Filter{}.And("artistId", msong.OpEq, "seven"),
+		},
+		{
+			name:   "pattern operator on the artist ID",
+			filter: msong.Filter{}.And("artistId", msong.OpPrefix, "7"),
+		},
+	})
+}
+
+func TestCountSongsTagsFilter(t *testing.T) {
+	const tagged = "id in ( select song_tags.song_id from song_tags join tags on tags.id = song_tags.tag_id " +
+		"where lower(tags.name) = any($1)"
+
+	runFilterTests(t, []filterTest{
+		{
+			name:      "any of the tags",
+			filter:    msong.Filter{}.And("tags", msong.OpEq, "Rock, ballad,rock"),
+			wantWhere: tagged + ")",
+			wantArgs:  []any{[]string{"rock", "ballad"}},
+		},
+		{
+			name:      "any of the tags spelled out",
+			filter:    msong.Filter{}.And("tags", msong.OpAny, "rock"),
+			wantWhere: tagged + ")",
+			wantArgs:  []any{[]string{"rock"}},
+		},
+		{
+			name:      "all of the tags",
+			filter:    msong.Filter{}.And("tags", msong.OpAll, "rock,ballad"),
+			wantWhere: tagged + " group by song_tags.song_id having count(*) = $2)",
+			wantArgs:  []any{[]string{"rock", "ballad"}, 2},
+		},
+	})
+
+	runInvalidFilterTests(t, []invalidFilterTest{
+		{
+			name:   "blank tag list",
+			filter: msong.Filter{}.And("tags", msong.OpAny, " , "),
+		},
+		{
+			name:   "pattern operator on the tags",
+			filter: msong.Filter{}.And("tags", msong.OpContains, "rock"),
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package songrepository
+
+import (
+	"context"
+	"errors"
+
+	msong "online-song-library/internal/model/song"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportSongs inserts the songs in one transaction and returns their IDs in
+// order. A song is skipped and gets ID 0 if its name matches, ignoring case,
+// a song of the artist its group resolves to by name or alias, the way the
+// songs trigger resolves it. Every song is checked as it is inserted, so this
+// covers songs earlier in the same batch. Songs pending enrichment get
+// enrichment jobs.
+func (sr *SongRepository) ImportSongs(ctx context.Context, songs []msong.Song) ([]uint64, error) {
+	releaseDates := make([]any, len(songs))
+	for i, song := range songs {
+		var err error
+		if releaseDates[i], err = releaseDateArg(song.ReleaseDate); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uint64, len(songs))
+
+	err := pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const sql = `
+		with inserted as (
+			insert into songs(
+				"group",
+				song,
+				release_date,
+				verses,
+				link,
+				enrichment_status
+			)
+			select $1::text, $2::text, $3::timestamp, $4::text[], $5::text, coalesce(nullif($6::text, ''), 'ready')
+			where not exists (
+				select 1
+				from songs
+				join artists on artists.id = songs.artist_id
+				where lower(songs.song) = lower($2)
+					and (
+						artist_key(artists.name) = artist_key($1)
+						or artist_key($1) in (select artist_key(alias) from unnest(artists.aliases) as alias)
+					)
+			)
+			returning id, enrichment_status
+		), queued as (
+			insert into enrichment_jobs(
+				song_id
+			)
+			select id from inserted where enrichment_status = 'pending'
+		)
+		select id from inserted;
+		`
+
+		batch := &pgx.Batch{}
+
+		for i, song := range songs {
+			verses := song.Verses
+			if verses == nil {
+				verses = []string{}
+			}
+
+			batch.Queue(sql, song.Group, song.Song, releaseDates[i], verses, song.Link, song.EnrichmentStatus)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+		defer results.Close()
+
+		for i := range songs {
+			// A skipped song returns no row and keeps ID 0.
+			if err := results.QueryRow().Scan(&ids[i]); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return mapError(err)
+			}
+		}
+
+		return results.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
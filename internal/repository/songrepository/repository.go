@@ -2,17 +2,24 @@ package songrepository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"online-song-library/internal/apperror"
 	msong "online-song-library/internal/model/song"
 	"online-song-library/pkg/dbstore"
+
+	"github.com/jackc/pgx/v5"
 )
 
+// SongRepository stores songs. Reads go through the song_listing view, so a
+// song without a release date reports the one of its earliest album.
 type SongRepository struct {
-	store dbstore.Store
+	store dbstore.DB
 }
 
-func NewSongRepository(store dbstore.Store) *SongRepository {
+func NewSongRepository(store dbstore.DB) *SongRepository {
 	return &SongRepository{
 		store: store,
 	}
@@ -20,51 +27,72 @@ func NewSongRepository(store dbstore.Store) *SongRepository {
 
 func (sr *SongRepository) GetPaginatedSongs(
 	ctx context.Context,
-	fields map[string]string,
-	offset, limit int,
-) (*[]msong.Song, error) {
-	sql := fmt.Sprintf(`
-	select
-		id,
-		"group",
-		song,
-		release_date,
-		link
-	from songs
-	where $1
-	offset $2
-	limit $3;
-	`)
-
-	where := fmt.Sprintf("where (%s)", func() string {
-		if len(fields) == 0 {
-			return "1=1"
-		}
+	filter msong.Filter,
+	page msong.Page,
+) (*msong.SongPage, error) {
+	args := queryArgs{}
+
+	where, err := buildWhere(filter, &args)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy, err := buildOrderBy(page.Sort)
+	if err != nil {
+		return nil, err
+	}
 
-		filter := ""
-		for k, v := range fields {
-			if filter != "" {
-				filter += " and "
-			}
+	keyset := "true"
+	offset := page.Offset - 1
 
-			filter += fmt.Sprintf("%s=%s", k, v)
+	if page.Cursor != nil {
+		keyset, err = buildKeyset(page.Sort, page.Cursor, &args)
+		if err != nil {
+			return nil, err
 		}
 
-		return filter
-	}())
+		offset = 0
+	}
 
-	rows, err := sr.store.Query(
-		ctx,
-		sql,
-		where,
-		offset,
-		limit,
+	// The window count runs over the filtered rows before the keyset, offset
+	// and limit apply, so total is the size of the whole listing. One extra
+	// row tells whether there is a next page.
+	sql := fmt.Sprintf(`
+	with filtered as (
+		select
+			id,
+			"group",
+			song,
+			release_date,
+			link,
+			count(*) over () as total
+		from song_listing
+		where %s
 	)
+	select
+		id,
+		"group",
+		song,
+		coalesce(to_char(release_date, 'DD.MM.YYYY'), ''),
+		link,
+		total
+	from filtered
+	where %s
+	order by %s
+	offset %s
+	limit %s;
+	`, where, keyset, orderBy, args.bind(offset), args.bind(page.Limit+1))
+
+	rows, err := sr.store.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	result := &msong.SongPage{
+		Songs: []msong.Song{},
 	}
 
-	songs := []msong.Song{}
 	for rows.Next() {
 		song := msong.Song{}
 		if err := rows.Scan(
@@ -73,21 +101,204 @@ func (sr *SongRepository) GetPaginatedSongs(
 			&song.Song,
 			&song.ReleaseDate,
 			&song.Link,
+			&result.Total,
 		); err != nil {
+			return nil, mapError(err)
+		}
+
+		result.Songs = append(result.Songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	// Past the end there are no rows to carry the window count.
+	if len(result.Songs) == 0 && (offset > 0 || page.Cursor != nil) {
+		result.Total, err = sr.CountSongs(ctx, filter)
+		if err != nil {
 			return nil, err
 		}
+	}
+
+	if len(result.Songs) > page.Limit {
+		result.Songs = result.Songs[:page.Limit]
+		result.HasNext = true
+		result.Next = nextCursor(page.Sort, result.Songs[len(result.Songs)-1])
+	}
+
+	return result, nil
+}
+
+func (sr *SongRepository) CountSongs(ctx context.Context, filter msong.Filter) (int, error) {
+	args := queryArgs{}
+
+	where, err := buildWhere(filter, &args)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := fmt.Sprintf(`
+	select
+		count(*)
+	from song_listing
+	where %s;
+	`, where)
+
+	var total int
+	if err := sr.store.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+		return 0, mapError(err)
+	}
+
+	return total, nil
+}
+
+func (sr *SongRepository) SearchSongs(
+	ctx context.Context,
+	query string,
+	filter msong.Filter,
+	offset, limit int,
+) (*[]msong.SearchResult, error) {
+	args := queryArgs{}
+	tsQuery := args.bind(query)
+
+	where, err := buildWhere(filter, &args)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`
+	with q as (
+		select websearch_to_tsquery('simple', %s) as query
+	)
+	select
+		s.id,
+		s."group",
+		s.song,
+		coalesce(to_char(s.release_date, 'DD.MM.YYYY'), ''),
+		s.link,
+		ts_rank(s.search_vector, q.query) as rank,
+		m.verse_numbers,
+		m.snippets
+	from song_listing s
+	cross join q
+	cross join lateral (
+		select
+			array_agg(v.n order by v.n) as verse_numbers,
+			array_agg(
+				ts_headline('simple', v.verse, q.query, 'StartSel=<b>, StopSel=</b>')
+				order by v.n
+			) as snippets
+		from unnest(s.verses) with ordinality as v(verse, n)
+		where to_tsvector('simple', v.verse) @@ q.query
+	) m
+	where s.search_vector @@ q.query and %s
+	order by rank desc, s.id
+	offset %s
+	limit %s;
+	`, tsQuery, where, args.bind(offset-1), args.bind(limit))
+
+	rows, err := sr.store.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	results := []msong.SearchResult{}
+	for rows.Next() {
+		var (
+			result       msong.SearchResult
+			verseNumbers []int
+			snippets     []string
+		)
+
+		if err := rows.Scan(
+			&result.ID,
+			&result.Group,
+			&result.Song,
+			&result.ReleaseDate,
+			&result.Link,
+			&result.Rank,
+			&verseNumbers,
+			&snippets,
+		); err != nil {
+			return nil, mapError(err)
+		}
+
+		result.Matches = make([]msong.VerseMatch, 0, len(verseNumbers))
+		for i, verse := range verseNumbers {
+			result.Matches = append(result.Matches, msong.VerseMatch{
+				Verse:   verse,
+				Snippet: snippets[i],
+			})
+		}
+
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &results, nil
+}
+
+func (sr *SongRepository) GetByID(ctx context.Context, id uint64) (*msong.Details, error) {
+	const sql = `
+	select
+		id,
+		"group",
+		song,
+		coalesce(to_char(release_date, 'DD.MM.YYYY'), ''),
+		link,
+		artist_id,
+		array(
+			select tags.name
+			from song_tags
+			join tags on tags.id = song_tags.tag_id
+			where song_tags.song_id = song_listing.id
+			order by lower(tags.name)
+		),
+		coalesce(array_length(verses, 1), 0),
+		enrichment_status,
+		created_at,
+		updated_at,
+		version
+	from song_listing
+	where id = $1;
+	`
+
+	details := new(msong.Details)
 
-		songs = append(songs, song)
+	if err := sr.store.QueryRow(
+		ctx,
+		sql,
+		id,
+	).Scan(
+		&details.ID,
+		&details.Group,
+		&details.Song,
+		&details.ReleaseDate,
+		&details.Link,
+		&details.ArtistID,
+		&details.Tags,
+		&details.VerseCount,
+		&details.EnrichmentStatus,
+		&details.CreatedAt,
+		&details.UpdatedAt,
+		&details.Version,
+	); err != nil {
+		return nil, mapError(err)
 	}
 
-	return &songs, nil
+	return details, nil
 }
 
-func (sr *SongRepository) GetPaginatedText(
+func (sr *SongRepository) GetPaginatedVerses(
 	ctx context.Context,
 	song msong.Song,
 	offset, limit int,
-) ([]string, error) {
+) ([]msong.Verse, error) {
 	const sql = `
 	select
 		verses[$1:$2]
@@ -95,18 +306,26 @@ func (sr *SongRepository) GetPaginatedText(
 	where id = $3;
 	`
 
-	verses := []string{}
+	texts := []string{}
 
 	if err := sr.store.QueryRow(
 		ctx,
 		sql,
 		offset,
-		limit,
+		offset+limit-1,
 		song.ID,
 	).Scan(
-		&verses,
+		&texts,
 	); err != nil {
-		return nil, err
+		return nil, mapError(err)
+	}
+
+	verses := make([]msong.Verse, 0, len(texts))
+	for i, text := range texts {
+		verses = append(verses, msong.Verse{
+			Index: offset + i,
+			Text:  text,
+		})
 	}
 
 	return verses, nil
@@ -115,21 +334,33 @@ func (sr *SongRepository) GetPaginatedText(
 func (sr *SongRepository) Delete(ctx context.Context, song msong.Song) error {
 	const sql = `
 	delete from songs
-	where id = $1;
+	where id = $1 and ($2 = 0 or version = $2);
 	`
 
-	if _, err := sr.store.Exec(
+	tag, err := sr.store.Exec(
 		ctx,
 		sql,
 		song.ID,
-	); err != nil {
-		return err
+		song.Version,
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return sr.writeMissed(ctx, song)
 	}
 
 	return nil
 }
 
-func (sr *SongRepository) Update(ctx context.Context, song msong.Song) error {
+// Update replaces the song and returns its new version.
+func (sr *SongRepository) Update(ctx context.Context, song msong.Song) (int, error) {
+	releaseDate, err := releaseDateArg(song.ReleaseDate)
+	if err != nil {
+		return 0, err
+	}
+
 	const sql = `
 	update
 		songs
@@ -137,48 +368,282 @@ func (sr *SongRepository) Update(ctx context.Context, song msong.Song) error {
 		"group" = $1,
 		song = $2,
 		release_date = $3,
-		text = $4,
-		link = $5
-	where id = $6;
+		verses = $4,
+		link = $5,
+		updated_at = now(),
+		version = version + 1
+	where id = $6 and ($7 = 0 or version = $7)
+	returning version;
 	`
 
-	if _, err := sr.store.Exec(
+	var version int
+	if err := sr.store.QueryRow(
 		ctx,
 		sql,
 		song.Group,
 		song.Song,
-		song.ReleaseDate,
+		releaseDate,
 		song.Verses,
 		song.Link,
+		song.ID,
+		song.Version,
+	).Scan(
+		&version,
 	); err != nil {
-		return err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, sr.writeMissed(ctx, song)
+		}
+
+		return 0, mapError(err)
 	}
 
-	return nil
+	return version, nil
 }
 
-func (sr *SongRepository) Create(ctx context.Context, song msong.Song) error {
+// writeMissed tells why a conditional write touched no rows: the song is
+// either gone or has moved past the expected version.
+func (sr *SongRepository) writeMissed(ctx context.Context, song msong.Song) error {
+	if song.Version == 0 {
+		return msong.ErrNotFound
+	}
+
 	const sql = `
-	insert into songs(
-		"group",
-		song,
-		release_date,
-		text,
-		link
-	) values ($1, $2, $3, $4, $5);
+	select
+		exists(select 1 from songs where id = $1);
 	`
 
-	if _, err := sr.store.Exec(
-		ctx,
-		sql,
-		song.Group,
-		song.Song,
-		song.ReleaseDate,
-		song.Verses,
-		song.Link,
-	); err != nil {
+	var exists bool
+	if err := sr.store.QueryRow(ctx, sql, song.ID).Scan(&exists); err != nil {
+		return mapError(err)
+	}
+
+	if !exists {
+		return msong.ErrNotFound
+	}
+
+	return msong.ErrVersionMismatch
+}
+
+// Patch applies a partial update inside a transaction that locks and reads
+// the current row first, so a missing song or a stale version is reported
+// before any write. It returns the new version.
+func (sr *SongRepository) Patch(ctx context.Context, id uint64, patch msong.Patch) (int, error) {
+	var version int
+
+	err := pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const lockSQL = `
+		select
+			version
+		from songs
+		where id = $1
+		for update;
+		`
+
+		var current int
+		if err := tx.QueryRow(ctx, lockSQL, id).Scan(&current); err != nil {
+			return mapError(err)
+		}
+
+		if patch.Version != 0 && patch.Version != current {
+			return msong.ErrVersionMismatch
+		}
+
+		args := queryArgs{}
+
+		set, err := buildSet(patch, &args)
+		if err != nil {
+			return err
+		}
+
+		sql := fmt.Sprintf(`
+		update
+			songs
+		set
+			%s
+		where id = %s
+		returning version;
+		`, set, args.bind(id))
+
+		if err := tx.QueryRow(ctx, sql, args...).Scan(&version); err != nil {
+			return mapError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// Create inserts the song and returns its id. A song pending enrichment
+// gets an enrichment job in the same transaction.
+func (sr *SongRepository) Create(ctx context.Context, song msong.Song) (uint64, error) {
+	releaseDate, err := releaseDateArg(song.ReleaseDate)
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint64
+
+	err = pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const sql = `
+		insert into songs(
+			"group",
+			song,
+			release_date,
+			verses,
+			link,
+			enrichment_status
+		) values ($1, $2, $3, $4, $5, coalesce(nullif($6, ''), 'ready'))
+		returning id;
+		`
+
+		if err := tx.QueryRow(
+			ctx,
+			sql,
+			song.Group,
+			song.Song,
+			releaseDate,
+			song.Verses,
+			song.Link,
+			song.EnrichmentStatus,
+		).Scan(
+			&id,
+		); err != nil {
+			return mapError(err)
+		}
+
+		if song.EnrichmentStatus != msong.EnrichmentPending {
+			return nil
+		}
+
+		return enqueueEnrichment(ctx, tx, id)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// releaseDateArg converts a release date to a query argument. Postgres would
+// read DD.MM.YYYY according to its DateStyle, so the date is parsed here.
+func releaseDateArg(value string) (any, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := msong.ParseReleaseDate(value)
+	if err != nil {
+		return nil, apperror.New(apperror.ErrValidation, err.Error())
+	}
+
+	return date, nil
+}
+
+// buildSet returns the set list for the fields present in patch.
+func buildSet(patch msong.Patch, args *queryArgs) (string, error) {
+	assignments := []string{}
+
+	if patch.Group != nil {
+		assignments = append(assignments, `"group" = `+args.bind(*patch.Group))
+	}
+
+	if patch.Song != nil {
+		assignments = append(assignments, "song = "+args.bind(*patch.Song))
+	}
+
+	if patch.ReleaseDate != nil {
+		releaseDate, err := releaseDateArg(*patch.ReleaseDate)
+		if err != nil {
+			return "", err
+		}
+
+		assignments = append(assignments, "release_date = "+args.bind(releaseDate))
+	}
+
+	if patch.Verses != nil {
+		assignments = append(assignments, "verses = "+args.bind(*patch.Verses))
+	}
+
+	if patch.Link != nil {
+		assignments = append(assignments, "link = "+args.bind(*patch.Link))
+	}
+
+	assignments = append(assignments, "updated_at = now()", "version = version + 1")
+
+	return strings.Join(assignments, ", "), nil
+}
+
+// ExportSongs passes the songs matching filter to fn in sort order. Rows are
+// read as they arrive from the server, the result is never held in memory.
+// Verses are only selected with withVerses.
+func (sr *SongRepository) ExportSongs(
+	ctx context.Context,
+	filter msong.Filter,
+	sort msong.Sort,
+	withVerses bool,
+	fn func(song msong.Song) error,
+) error {
+	args := queryArgs{}
+
+	where, err := buildWhere(filter, &args)
+	if err != nil {
+		return err
+	}
+
+	orderBy, err := buildOrderBy(sort)
+	if err != nil {
 		return err
 	}
 
+	verses := "'{}'::text[]"
+	if withVerses {
+		verses = "verses"
+	}
+
+	sql := fmt.Sprintf(`
+	select
+		id,
+		"group",
+		song,
+		coalesce(to_char(release_date, 'DD.MM.YYYY'), ''),
+		link,
+		%s
+	from song_listing
+	where %s
+	order by %s;
+	`, verses, where, orderBy)
+
+	rows, err := sr.store.Query(ctx, sql, args...)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		song := msong.Song{}
+		if err := rows.Scan(
+			&song.ID,
+			&song.Group,
+			&song.Song,
+			&song.ReleaseDate,
+			&song.Link,
+			&song.Verses,
+		); err != nil {
+			return mapError(err)
+		}
+
+		if err := fn(song); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return mapError(err)
+	}
+
 	return nil
 }
@@ -0,0 +1,370 @@
+package songrepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/pgtest"
+	"online-song-library/internal/repository/songrepository"
+)
+
+// newRepository returns a repository over an empty test database.
+func newRepository(t *testing.T) *songrepository.SongRepository {
+	t.Helper()
+
+	return songrepository.NewSongRepository(pgtest.New(t))
+}
+
+// mustCreate stores the song and returns its ID.
+func mustCreate(t *testing.T, repo *songrepository.SongRepository, song msong.Song) uint64 {
+	t.Helper()
+
+	if song.Verses == nil {
+		song.Verses = []string{}
+	}
+
+	id, err := repo.Create(context.Background(), song)
+	if err != nil {
+		t.Fatalf("Create(%s - %s) error = %v", song.Group, song.Song, err)
+	}
+
+	return id
+}
+
+func mustGet(t *testing.T, repo *songrepository.SongRepository, id uint64) *msong.Details {
+	t.Helper()
+
+	details, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID(%d) error = %v", id, err)
+	}
+
+	return details
+}
+
+func TestCreateAndGet(t *testing.T) {
+	repo := newRepository(t)
+
+	id := mustCreate(t, repo, msong.Song{
+		Group:       "Muse",
+		Song:        "Supermassive Black Hole",
+		ReleaseDate: "2006-07-16",
+		Verses:      []string{"Ooh baby, don't you know I suffer?", "I thought I was a fool for no one"},
+		Link:        "https://example.com/smbh",
+	})
+
+	got := mustGet(t, repo, id)
+
+	if got.Group != "Muse" || got.Song.Song != "Supermassive Black Hole" || got.Link != "https://example.com/smbh" {
+		t.Errorf("GetByID() = %+v", got)
+	}
+
+	if got.ReleaseDate != "16.07.2006" {
+		t.Errorf("GetByID().ReleaseDate = %q, want 16.07.2006", got.ReleaseDate)
+	}
+
+	if got.VerseCount != 2 || got.Version != 1 || got.EnrichmentStatus != msong.EnrichmentReady {
+		t.Errorf("GetByID() verses %d, version %d, status %q, want 2, 1, ready",
+			got.VerseCount, got.Version, got.EnrichmentStatus)
+	}
+
+	if got.ArtistID == 0 {
+		t.Error("GetByID().ArtistID = 0, want the artist the group resolves to")
+	}
+
+	verses, err := repo.GetPaginatedVerses(context.Background(), msong.Song{ID: id}, 2, 10)
+	if err != nil {
+		t.Fatalf("GetPaginatedVerses() error = %v", err)
+	}
+
+	want := []msong.Verse{{Index: 2, Text: "I thought I was a fool for no one"}}
+	if !reflect.DeepEqual(verses, want) {
+		t.Errorf("GetPaginatedVerses() = %v, want %v", verses, want)
+	}
+
+	if _, err := repo.GetByID(context.Background(), id+1); !errors.Is(err, msong.ErrNotFound) {
+		t.Errorf("GetByID() of a missing song error = %v, want %v", err, msong.ErrNotFound)
+	}
+}
+
+func TestConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+	id := mustCreate(t, repo, msong.Song{Group: "Muse", Song: "Hysteria"})
+
+	song := msong.Song{ID: id, Group: "Muse", Song: "Hysteria", Verses: []string{"It's bugging me"}, Version: 1}
+
+	version, err := repo.Update(ctx, song)
+	if err != nil || version != 2 {
+		t.Fatalf("Update() = %d, %v, want 2", version, err)
+	}
+
+	if _, err := repo.Update(ctx, song); !errors.Is(err, msong.ErrVersionMismatch) {
+		t.Errorf("Update() of a stale version error = %v, want %v", err, msong.ErrVersionMismatch)
+	}
+
+	title := "Hysteria (live)"
+
+	version, err = repo.Patch(ctx, id, msong.Patch{Song: &title, Version: 2})
+	if err != nil || version != 3 {
+		t.Fatalf("Patch() = %d, %v, want 3", version, err)
+	}
+
+	if got := mustGet(t, repo, id); got.Song.Song != title || got.VerseCount != 1 {
+		t.Errorf("after Patch() song = %q with %d verses, want %q with 1", got.Song.Song, got.VerseCount, title)
+	}
+
+	if _, err := repo.Patch(ctx, id, msong.Patch{Song: &title, Version: 2}); !errors.Is(err, msong.ErrVersionMismatch) {
+		t.Errorf("Patch() of a stale version error = %v, want %v", err, msong.ErrVersionMismatch)
+	}
+
+	if err := repo.Delete(ctx, msong.Song{ID: id, Version: 2}); !errors.Is(err, msong.ErrVersionMismatch) {
+		t.Errorf("Delete() of a stale version error = %v, want %v", err, msong.ErrVersionMismatch)
+	}
+
+	if err := repo.Delete(ctx, msong.Song{ID: id, Version: 3}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, msong.Song{ID: id}); !errors.Is(err, msong.ErrNotFound) {
+		t.Errorf("Delete() of a deleted song error = %v, want %v", err, msong.ErrNotFound)
+	}
+}
+
+func TestGetPaginatedSongsFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+
+	mustCreate(t, repo, msong.Song{Group: "Muse", Song: "Hysteria", ReleaseDate: "01.12.2003"})
+	mustCreate(t, repo, msong.Song{Group: "Muse", Song: "100%_Pure", ReleaseDate: "15.09.2003"})
+	mustCreate(t, repo, msong.Song{Group: "Radiohead", Song: "Creep", ReleaseDate: "21.09.1992"})
+
+	tests := []struct {
+		name   string
+		filter msong.Filter
+		want   []string
+	}{
+		{
+			name:   "exact group",
+			filter: msong.Filter{}.And("group", msong.OpEq, "Muse"),
+			want:   []string{"Hysteria", "100%_Pure"},
+		},
+		{
+			name:   "wildcards are literal in contains",
+			filter: msong.Filter{}.And("song", msong.OpContains, "0%_p"),
+			want:   []string{"100%_Pure"},
+		},
+		{
+			name: "release date range",
+			filter: msong.Filter{}.
+				And("releaseDate", msong.OpFrom, "2003-01-01").
+				And("releaseDate", msong.OpTo, "15.09.2003"),
+			want: []string{"100%_Pure"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.GetPaginatedSongs(ctx, tt.filter, msong.Page{Offset: 1, Limit: 10})
+			if err != nil {
+				t.Fatalf("GetPaginatedSongs() error = %v", err)
+			}
+
+			got := make([]string, 0, len(page.Songs))
+			for _, song := range page.Songs {
+				got = append(got, song.Song)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) || page.Total != len(tt.want) {
+				t.Errorf("GetPaginatedSongs() = %v of %d, want %v", got, page.Total, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetPaginatedSongsCursor pages through the songs by cursor and checks
+// that the pages add up to the listing read by offset in the same order.
+func TestGetPaginatedSongsCursor(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+
+	for _, song := range []msong.Song{
+		{Group: "Muse", Song: "Hysteria", ReleaseDate: "01.12.2003"},
+		{Group: "Muse", Song: "Time Is Running Out", ReleaseDate: "01.12.2003"},
+		{Group: "Muse", Song: "Uprising", ReleaseDate: "07.09.2009"},
+		{Group: "Muse", Song: "Unreleased"},
+		{Group: "Radiohead", Song: "Creep", ReleaseDate: "21.09.1992"},
+	} {
+		mustCreate(t, repo, song)
+	}
+
+	for _, sort := range []string{"releaseDate", "-releaseDate", "group,-song"} {
+		t.Run(sort, func(t *testing.T) {
+			order, err := msong.ParseSort(sort)
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+
+			all, err := repo.GetPaginatedSongs(ctx, msong.Filter{}, msong.Page{Offset: 1, Limit: 10, Sort: order})
+			if err != nil {
+				t.Fatalf("GetPaginatedSongs() error = %v", err)
+			}
+
+			page := msong.Page{Offset: 1, Limit: 2, Sort: order}
+			got := []uint64{}
+
+			for range len(all.Songs) {
+				result, err := repo.GetPaginatedSongs(ctx, msong.Filter{}, page)
+				if err != nil {
+					t.Fatalf("GetPaginatedSongs() error = %v", err)
+				}
+
+				for _, song := range result.Songs {
+					got = append(got, song.ID)
+				}
+
+				if !result.HasNext {
+					break
+				}
+
+				page.Cursor = result.Next
+			}
+
+			want := make([]uint64, 0, len(all.Songs))
+			for _, song := range all.Songs {
+				want = append(want, song.ID)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("cursor pages = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestImportSongsSkipsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+
+	existing := mustCreate(t, repo, msong.Song{Group: "The Beatles", Song: "Yesterday"})
+
+	ids, err := repo.ImportSongs(ctx, []msong.Song{
+		{Group: "beatles", Song: "YESTERDAY"},
+		{Group: "Muse", Song: "Hysteria", EnrichmentStatus: msong.EnrichmentPending},
+		{Group: "The Muse", Song: "hysteria"},
+	})
+	if err != nil {
+		t.Fatalf("ImportSongs() error = %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != 0 || ids[1] == 0 || ids[1] == existing || ids[2] != 0 {
+		t.Fatalf("ImportSongs() = %v, want 0 for the existing song, a new ID and 0 for the repeated one", ids)
+	}
+
+	jobs, err := repo.ClaimEnrichmentJobs(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimEnrichmentJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Song.ID != ids[1] {
+		t.Errorf("ClaimEnrichmentJobs() = %+v, want the job of song %d", jobs, ids[1])
+	}
+}
+
+// TestEnrichmentBumpsVersion checks that every enrichment status change is
+// a new version of the song, so cached copies and ETags go stale.
+func TestEnrichmentBumpsVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+
+	id := mustCreate(t, repo, msong.Song{Group: "Muse", Song: "Hysteria", EnrichmentStatus: msong.EnrichmentPending})
+
+	claim := func() msong.EnrichmentJob {
+		t.Helper()
+
+		jobs, err := repo.ClaimEnrichmentJobs(ctx, 10, time.Minute)
+		if err != nil || len(jobs) != 1 {
+			t.Fatalf("ClaimEnrichmentJobs() = %+v, %v, want one job", jobs, err)
+		}
+
+		return jobs[0]
+	}
+
+	check := func(step, status string, version int) {
+		t.Helper()
+
+		if got := mustGet(t, repo, id); got.EnrichmentStatus != status || got.Version != version {
+			t.Errorf("after %s status %q version %d, want %q version %d",
+				step, got.EnrichmentStatus, got.Version, status, version)
+		}
+	}
+
+	if err := repo.FailEnrichmentJob(ctx, claim()); err != nil {
+		t.Fatalf("FailEnrichmentJob() error = %v", err)
+	}
+
+	check("FailEnrichmentJob", msong.EnrichmentFailed, 2)
+
+	if err := repo.RequeueEnrichment(ctx, id); err != nil {
+		t.Fatalf("RequeueEnrichment() error = %v", err)
+	}
+
+	check("RequeueEnrichment", msong.EnrichmentPending, 3)
+
+	detail := msong.Song{ReleaseDate: "01.12.2003", Verses: []string{"It's bugging me"}, Link: "https://example.com/h"}
+	if err := repo.CompleteEnrichmentJob(ctx, claim(), detail); err != nil {
+		t.Fatalf("CompleteEnrichmentJob() error = %v", err)
+	}
+
+	check("CompleteEnrichmentJob", msong.EnrichmentReady, 4)
+
+	queued, err := repo.RequeueAllEnrichment(ctx, msong.EnrichmentReady)
+	if err != nil || queued != 1 {
+		t.Fatalf("RequeueAllEnrichment() = %d, %v, want 1", queued, err)
+	}
+
+	check("RequeueAllEnrichment", msong.EnrichmentPending, 5)
+}
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t)
+
+	hysteria := mustCreate(t, repo, msong.Song{Group: "Muse", Song: "Hysteria"})
+	creep := mustCreate(t, repo, msong.Song{Group: "Radiohead", Song: "Creep"})
+
+	version, err := repo.AttachTags(ctx, hysteria, []string{"Rock", "alternative"})
+	if err != nil || version != 2 {
+		t.Fatalf("AttachTags() = %d, %v, want 2", version, err)
+	}
+
+	if version, err = repo.AttachTags(ctx, creep, []string{"rock"}); err != nil || version != 2 {
+		t.Fatalf("AttachTags() = %d, %v, want 2", version, err)
+	}
+
+	if got := mustGet(t, repo, hysteria).Tags; !reflect.DeepEqual(got, []string{"alternative", "Rock"}) {
+		t.Errorf("GetByID().Tags = %v, want [alternative Rock]", got)
+	}
+
+	counts, err := repo.TagCounts(ctx, msong.Filter{})
+	if err != nil {
+		t.Fatalf("TagCounts() error = %v", err)
+	}
+
+	want := []msong.TagCount{{Name: "Rock", Songs: 2}, {Name: "alternative", Songs: 1}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("TagCounts() = %v, want %v", counts, want)
+	}
+
+	if version, err = repo.DetachTag(ctx, hysteria, "ROCK"); err != nil || version != 3 {
+		t.Fatalf("DetachTag() = %d, %v, want 3", version, err)
+	}
+
+	if _, err := repo.DetachTag(ctx, hysteria, "rock"); !errors.Is(err, msong.ErrTagNotFound) {
+		t.Errorf("DetachTag() of a removed tag error = %v, want %v", err, msong.ErrTagNotFound)
+	}
+}
@@ -0,0 +1,132 @@
+package songrepository
+
+import (
+	"fmt"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// unknownReleaseDate sorts songs that have no release date yet before all others.
+const unknownReleaseDate = "-infinity"
+
+// sortColumn returns the sort expression of a whitelisted field. Nullable
+// columns are coalesced so that keyset comparisons never see null. Release
+// dates are compared by day, the precision cursors carry them in.
+func sortColumn(field string) (string, bool) {
+	column, ok := songColumns[field]
+	if column == "release_date" {
+		column = fmt.Sprintf("coalesce(release_date::date, '%s')", unknownReleaseDate)
+	}
+
+	return column, ok
+}
+
+// buildOrderBy validates sort against the column whitelist and returns the
+// order by list, always ending with id as a tiebreaker.
+func buildOrderBy(sort msong.Sort) (string, error) {
+	terms := make([]string, 0, len(sort)+1)
+	for _, key := range sort {
+		column, ok := sortColumn(key.Field)
+		if !ok {
+			return "", fmt.Errorf("%w: unknown field %q", msong.ErrInvalidSort, key.Field)
+		}
+
+		if key.Desc {
+			column += " desc"
+		}
+
+		terms = append(terms, column)
+	}
+
+	return strings.Join(append(terms, "id"), ", "), nil
+}
+
+// buildKeyset returns the predicate selecting rows that come after cursor in
+// the given order: (k1 > v1) or (k1 = v1 and k2 > v2) or ... or (... and id > v).
+func buildKeyset(sort msong.Sort, cursor *msong.Cursor, args *queryArgs) (string, error) {
+	if cursor.Sort != sort.String() || len(cursor.Keys) != len(sort) {
+		return "", fmt.Errorf("%w: cursor was issued for a different sort", msong.ErrInvalidCursor)
+	}
+
+	columns := make([]string, 0, len(sort)+1)
+	values := make([]string, 0, len(sort)+1)
+	ops := make([]string, 0, len(sort)+1)
+
+	for i, key := range sort {
+		column, _ := sortColumn(key.Field)
+
+		value, err := sortKeyArg(key.Field, cursor.Keys[i])
+		if err != nil {
+			return "", err
+		}
+
+		op := ">"
+		if key.Desc {
+			op = "<"
+		}
+
+		columns = append(columns, column)
+		values = append(values, args.bind(value))
+		ops = append(ops, op)
+	}
+
+	columns = append(columns, "id")
+	values = append(values, args.bind(cursor.ID))
+	ops = append(ops, ">")
+
+	alternatives := make([]string, 0, len(columns))
+	for i := range columns {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("%s = %s", columns[j], values[j]))
+		}
+
+		terms = append(terms, fmt.Sprintf("%s %s %s", columns[i], ops[i], values[i]))
+		alternatives = append(alternatives, "("+strings.Join(terms, " and ")+")")
+	}
+
+	return strings.Join(alternatives, " or "), nil
+}
+
+func sortKeyArg(field, value string) (any, error) {
+	if field != "releaseDate" {
+		return value, nil
+	}
+
+	if value == "" {
+		return pgtype.Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, nil
+	}
+
+	date, err := msong.ParseReleaseDate(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", msong.ErrInvalidCursor, err)
+	}
+
+	return pgtype.Date{Time: date, Valid: true}, nil
+}
+
+// nextCursor builds the cursor pointing right after song.
+func nextCursor(sort msong.Sort, song msong.Song) *msong.Cursor {
+	keys := make([]string, 0, len(sort))
+	for _, key := range sort {
+		switch key.Field {
+		case "group":
+			keys = append(keys, song.Group)
+		case "song":
+			keys = append(keys, song.Song)
+		case "releaseDate":
+			keys = append(keys, song.ReleaseDate)
+		case "link":
+			keys = append(keys, song.Link)
+		}
+	}
+
+	return &msong.Cursor{
+		Sort: sort.String(),
+		Keys: keys,
+		ID:   song.ID,
+	}
+}
@@ -0,0 +1,85 @@
+package songrepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/repository/songrepository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestGetPaginatedSongsReleaseDateCursor(t *testing.T) {
+	tests := []struct {
+		name      string
+		sort      string
+		key       string
+		wantWhere string
+		wantArg   pgtype.Date
+	}{
+		{
+			name: "ascending",
+			sort: "releaseDate",
+			key:  "16.07.2006",
+			wantWhere: "(coalesce(release_date::date, '-infinity') > $1) or " +
+				"(coalesce(release_date::date, '-infinity') = $1 and id > $2)",
+			wantArg: pgtype.Date{Time: date(2006, time.July, 16), Valid: true},
+		},
+		{
+			name: "descending",
+			sort: "-releaseDate",
+			key:  "16.07.2006",
+			wantWhere: "(coalesce(release_date::date, '-infinity') < $1) or " +
+				"(coalesce(release_date::date, '-infinity') = $1 and id > $2)",
+			wantArg: pgtype.Date{Time: date(2006, time.July, 16), Valid: true},
+		},
+		{
+			name: "song without a release date",
+			sort: "releaseDate",
+			key:  "",
+			wantWhere: "(coalesce(release_date::date, '-infinity') > $1) or " +
+				"(coalesce(release_date::date, '-infinity') = $1 and id > $2)",
+			wantArg: pgtype.Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := msong.ParseSort(tt.sort)
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+
+			page := msong.Page{
+				Limit: 10,
+				Sort:  sort,
+				Cursor: &msong.Cursor{
+					Sort: sort.String(),
+					Keys: []string{tt.key},
+					ID:   5,
+				},
+			}
+
+			store := &fakeStore{}
+
+			_, err = songrepository.NewSongRepository(store).GetPaginatedSongs(context.Background(), msong.Filter{}, page)
+			if !errors.Is(err, errNotImplemented) {
+				t.Fatalf("GetPaginatedSongs() error = %v, want the store error", err)
+			}
+
+			sql := strings.Join(strings.Fields(store.sql), " ")
+			if !strings.Contains(sql, "where "+tt.wantWhere+" order by coalesce(release_date::date, '-infinity')") {
+				t.Errorf("GetPaginatedSongs() sql = %q, want keyset %q", sql, tt.wantWhere)
+			}
+
+			if len(store.args) == 0 || !reflect.DeepEqual(store.args[0], tt.wantArg) {
+				t.Errorf("GetPaginatedSongs() args = %#v, want the cursor date %#v first", store.args, tt.wantArg)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package songrepository
+
+import (
+	"context"
+
+	msong "online-song-library/internal/model/song"
+)
+
+func (sr *SongRepository) GetStats(ctx context.Context) (*msong.Stats, error) {
+	const sql = `
+	select
+		count(*),
+		count(distinct "group"),
+		coalesce(sum(coalesce(array_length(verses, 1), 0)), 0),
+		count(*) filter (where enrichment_status = 'pending'),
+		count(*) filter (where enrichment_status = 'ready'),
+		count(*) filter (where enrichment_status = 'failed'),
+		(select count(*) from enrichment_jobs),
+		coalesce(to_char(min(release_date), 'DD.MM.YYYY'), ''),
+		coalesce(to_char(max(release_date), 'DD.MM.YYYY'), '')
+	from song_listing;
+	`
+
+	stats := new(msong.Stats)
+
+	if err := sr.store.QueryRow(ctx, sql).Scan(
+		&stats.Songs,
+		&stats.Groups,
+		&stats.Verses,
+		&stats.Pending,
+		&stats.Ready,
+		&stats.Failed,
+		&stats.QueuedJobs,
+		&stats.EarliestRelease,
+		&stats.LatestRelease,
+	); err != nil {
+		return nil, mapError(err)
+	}
+
+	return stats, nil
+}
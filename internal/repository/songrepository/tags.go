@@ -0,0 +1,193 @@
+package songrepository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AttachTags tags the song, creating the tags that do not exist yet, and
+// returns the song version. The version is bumped only if a tag was added.
+func (sr *SongRepository) AttachTags(ctx context.Context, id uint64, names []string) (int, error) {
+	var version int
+
+	err := pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const lockSQL = `
+		select
+			version
+		from songs
+		where id = $1
+		for update;
+		`
+
+		if err := tx.QueryRow(ctx, lockSQL, id).Scan(&version); err != nil {
+			return mapError(err)
+		}
+
+		const tagsSQL = `
+		insert into tags(
+			name
+		)
+		select unnest($1::text[])
+		on conflict (lower(name)) do nothing;
+		`
+
+		if _, err := tx.Exec(ctx, tagsSQL, names); err != nil {
+			return mapError(err)
+		}
+
+		const attachSQL = `
+		insert into song_tags(
+			song_id,
+			tag_id
+		)
+		select $1, id from tags where lower(name) = any($2)
+		on conflict do nothing;
+		`
+
+		tag, err := tx.Exec(ctx, attachSQL, id, lowerAll(names))
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		version, err = bumpVersion(ctx, tx, id)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// DetachTag removes the tag from the song and returns the new song version.
+// The tag itself is kept for other songs.
+func (sr *SongRepository) DetachTag(ctx context.Context, id uint64, name string) (int, error) {
+	var version int
+
+	err := pgx.BeginFunc(ctx, sr.store, func(tx pgx.Tx) error {
+		const sql = `
+		delete from song_tags
+		using tags
+		where song_tags.tag_id = tags.id
+			and song_tags.song_id = $1
+			and lower(tags.name) = lower($2);
+		`
+
+		tag, err := tx.Exec(ctx, sql, id, name)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			const existsSQL = `
+			select
+				exists(select 1 from songs where id = $1);
+			`
+
+			var exists bool
+			if err := tx.QueryRow(ctx, existsSQL, id).Scan(&exists); err != nil {
+				return mapError(err)
+			}
+
+			if !exists {
+				return msong.ErrNotFound
+			}
+
+			return msong.ErrTagNotFound
+		}
+
+		version, err = bumpVersion(ctx, tx, id)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// TagCounts counts the songs matching filter per tag, most used first.
+// Tags none of these songs carry are left out.
+func (sr *SongRepository) TagCounts(ctx context.Context, filter msong.Filter) ([]msong.TagCount, error) {
+	args := queryArgs{}
+
+	where, err := buildWhere(filter, &args)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`
+	select
+		tags.name,
+		count(*)
+	from song_tags
+	join tags on tags.id = song_tags.tag_id
+	where song_tags.song_id in (
+		select id from song_listing where %s
+	)
+	group by tags.id, tags.name
+	order by count(*) desc, lower(tags.name);
+	`, where)
+
+	rows, err := sr.store.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	counts := []msong.TagCount{}
+
+	for rows.Next() {
+		count := msong.TagCount{}
+		if err := rows.Scan(&count.Name, &count.Songs); err != nil {
+			return nil, mapError(err)
+		}
+
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	return counts, nil
+}
+
+func bumpVersion(ctx context.Context, tx pgx.Tx, id uint64) (int, error) {
+	const sql = `
+	update
+		songs
+	set
+		updated_at = now(),
+		version = version + 1
+	where id = $1
+	returning version;
+	`
+
+	var version int
+	if err := tx.QueryRow(ctx, sql, id).Scan(&version); err != nil {
+		return 0, mapError(err)
+	}
+
+	return version, nil
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+
+	return lowered
+}
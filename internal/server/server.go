@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,9 +12,16 @@ import (
 	"online-song-library/internal/bootstrap"
 	"online-song-library/internal/clients/infoservice"
 	"online-song-library/internal/config"
+	"online-song-library/internal/enrichment"
 	"online-song-library/internal/handler"
+	"online-song-library/internal/metadata"
+	"online-song-library/internal/repository/albumrepository"
+	"online-song-library/internal/repository/artistrepository"
+	"online-song-library/internal/repository/playlistrepository"
+	"online-song-library/internal/repository/songinforepository"
 	"online-song-library/internal/repository/songrepository"
 	"online-song-library/internal/service"
+	"online-song-library/pkg/dbstore"
 
 	"github.com/sirupsen/logrus"
 )
@@ -28,10 +36,51 @@ func Run(cfg *config.Config) error {
 		logrus.Fatalf("Failed to connect postgres %s, %v", cfg.PgDSN, err)
 	}
 
+	if cfg.MigrateOnStart {
+		applied, err := bootstrap.MigrateUp(ctx, pgConnPool)
+		if err != nil {
+			logrus.Fatalf("Failed to apply migrations: %v", err)
+		}
+
+		logrus.Infof("Applied %d migrations", applied)
+	}
+
 	songRepository := songrepository.NewSongRepository(pgConnPool)
-	client := infoservice.NewMusicInfoClient(cfg)
-	service := service.NewService(songRepository, client)
-	handler := handler.NewHandler(service)
+	artistRepository := artistrepository.NewArtistRepository(pgConnPool)
+	albumRepository := albumrepository.NewAlbumRepository(pgConnPool)
+	playlistRepository := playlistrepository.NewPlaylistRepository(pgConnPool)
+	fallback, err := service.ParseFallbackPolicy(cfg.MusicInfoFallback)
+	if err != nil {
+		logrus.Fatalf("Invalid music info fallback: %v", err)
+	}
+
+	cache, err := newMusicInfoCache(cfg, pgConnPool)
+	if err != nil {
+		logrus.Fatalf("Failed to create music info cache: %v", err)
+	}
+
+	client, err := infoservice.NewMusicInfoClient(cfg, cache)
+	if err != nil {
+		logrus.Fatalf("Failed to create music info client: %v", err)
+	}
+
+	provider, err := metadata.New(cfg, client)
+	if err != nil {
+		logrus.Fatalf("Failed to create metadata provider: %v", err)
+	}
+
+	pool := enrichment.NewPool(songRepository, provider, cfg)
+	pool.Start()
+
+	service := service.NewService(
+		songRepository,
+		artistRepository,
+		albumRepository,
+		playlistRepository,
+		provider,
+		fallback,
+	)
+	handler := handler.NewHandler(service, cfg.BulkTimeout)
 
 	server := &http.Server{
 		Addr:           ":" + cfg.Port,
@@ -49,12 +98,25 @@ func Run(cfg *config.Config) error {
 		}
 	}()
 
-	gracefulShotdown(ctx, server, cancel)
+	gracefulShotdown(ctx, server, pool, cancel)
 
 	return nil
 }
 
-func gracefulShotdown(ctx context.Context, s *http.Server, cancel context.CancelFunc) {
+func newMusicInfoCache(cfg *config.Config, store dbstore.Store) (infoservice.Cache, error) {
+	switch cfg.MusicInfoCache {
+	case "memory":
+		return infoservice.NewMemoryCache(cfg.MusicInfoCacheSize), nil
+	case "postgres":
+		return songinforepository.NewSongInfoRepository(store), nil
+	case "none", "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown music info cache %q", cfg.MusicInfoCache)
+	}
+}
+
+func gracefulShotdown(ctx context.Context, s *http.Server, pool *enrichment.Pool, cancel context.CancelFunc) {
 	const waitTime = 5 * time.Second // waiting time before closing all connections
 
 	ch := make(chan os.Signal, 1)
@@ -69,6 +131,13 @@ func gracefulShotdown(ctx context.Context, s *http.Server, cancel context.Cancel
 		logrus.Errorf("Error shutting down server: %v", err)
 	}
 
+	drainCtx, drainCancel := context.WithTimeout(ctx, waitTime)
+	defer drainCancel()
+
+	if err := pool.Drain(drainCtx); err != nil {
+		logrus.Errorf("Error draining enrichment workers: %v", err)
+	}
+
 	cancel()
 	time.Sleep(waitTime)
 	logrus.Info("Graceful shutdown completed.")
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	malbum "online-song-library/internal/model/album"
+)
+
+func (service *Service) ListAlbums(
+	ctx context.Context,
+	artistID uint64,
+	title string,
+	offset, limit int,
+) (*malbum.AlbumPage, error) {
+	return service.albumRepository.List(ctx, artistID, strings.TrimSpace(title), offset, limit)
+}
+
+func (service *Service) GetAlbum(ctx context.Context, id uint64) (*malbum.Album, error) {
+	return service.albumRepository.GetByID(ctx, id)
+}
+
+func (service *Service) CreateAlbum(ctx context.Context, album malbum.Album) (uint64, error) {
+	return service.albumRepository.Create(ctx, normalizeAlbum(album))
+}
+
+func (service *Service) UpdateAlbum(ctx context.Context, album malbum.Album) error {
+	return service.albumRepository.Update(ctx, normalizeAlbum(album))
+}
+
+func (service *Service) DeleteAlbum(ctx context.Context, id uint64) error {
+	return service.albumRepository.Delete(ctx, id)
+}
+
+func (service *Service) GetAlbumTracks(ctx context.Context, id uint64) ([]malbum.Track, error) {
+	return service.albumRepository.Tracks(ctx, id)
+}
+
+func (service *Service) SetAlbumTrack(ctx context.Context, albumID uint64, track malbum.Track) error {
+	return service.albumRepository.SetTrack(ctx, albumID, track)
+}
+
+func (service *Service) RemoveAlbumTrack(ctx context.Context, albumID, songID uint64) error {
+	return service.albumRepository.RemoveTrack(ctx, albumID, songID)
+}
+
+func normalizeAlbum(album malbum.Album) malbum.Album {
+	album.Title = strings.TrimSpace(album.Title)
+	album.Artist = strings.TrimSpace(album.Artist)
+
+	return album
+}
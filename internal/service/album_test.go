@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	malbum "online-song-library/internal/model/album"
+	"online-song-library/internal/service"
+
+	"go.uber.org/mock/gomock"
+)
+
+var absolution = malbum.Album{ID: 5, Title: "Absolution", ArtistID: 3, Artist: "Muse", ReleaseDate: "15.09.2003"}
+
+var albumTests = []serviceTest{
+	{
+		name: "ListAlbums trims the title",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().
+				List(gomock.Any(), uint64(3), "abso", 1, 10).
+				Return(&malbum.AlbumPage{Albums: []malbum.Album{absolution}, Total: 1}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.ListAlbums(ctx, 3, " abso ", 1, 10)
+		},
+		want: &malbum.AlbumPage{Albums: []malbum.Album{absolution}, Total: 1},
+	},
+	{
+		name: "GetAlbum",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().GetByID(gomock.Any(), uint64(5)).Return(&absolution, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetAlbum(ctx, 5)
+		},
+		want: &absolution,
+	},
+	{
+		name: "GetAlbum not found",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().GetByID(gomock.Any(), uint64(6)).Return(nil, malbum.ErrNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetAlbum(ctx, 6)
+		},
+		wantErr: malbum.ErrNotFound,
+	},
+	{
+		name: "CreateAlbum trims title and artist",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().
+				Create(gomock.Any(), malbum.Album{Title: "Absolution", Artist: "Muse"}).
+				Return(uint64(5), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateAlbum(ctx, malbum.Album{Title: "Absolution ", Artist: " Muse"})
+		},
+		want: uint64(5),
+	},
+	{
+		name: "UpdateAlbum trims title and artist",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().Update(gomock.Any(), malbum.Album{ID: 5, Title: "Absolution", ArtistID: 3}).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.UpdateAlbum(ctx, malbum.Album{ID: 5, Title: "\tAbsolution", ArtistID: 3, Artist: " "})
+		},
+	},
+	{
+		name: "DeleteAlbum",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().Delete(gomock.Any(), uint64(5)).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.DeleteAlbum(ctx, 5)
+		},
+	},
+	{
+		name: "GetAlbumTracks",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().Tracks(gomock.Any(), uint64(5)).Return([]malbum.Track{{Disc: 1, Track: 8, Song: hysteria}}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetAlbumTracks(ctx, 5)
+		},
+		want: []malbum.Track{{Disc: 1, Track: 8, Song: hysteria}},
+	},
+	{
+		name: "SetAlbumTrack",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().SetTrack(gomock.Any(), uint64(5), malbum.Track{Disc: 1, Track: 8, Song: hysteria}).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.SetAlbumTrack(ctx, 5, malbum.Track{Disc: 1, Track: 8, Song: hysteria})
+		},
+	},
+	{
+		name: "RemoveAlbumTrack",
+		setup: func(f *fixture) {
+			f.albums.EXPECT().RemoveTrack(gomock.Any(), uint64(5), uint64(7)).Return(malbum.ErrTrackNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.RemoveAlbumTrack(ctx, 5, 7)
+		},
+		wantErr: malbum.ErrTrackNotFound,
+	},
+}
+
+func TestAlbums(t *testing.T) {
+	runServiceTests(t, albumTests)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	martist "online-song-library/internal/model/artist"
+	msong "online-song-library/internal/model/song"
+)
+
+func (service *Service) ListArtists(
+	ctx context.Context,
+	name string,
+	offset, limit int,
+) (*martist.ArtistPage, error) {
+	return service.artistRepository.List(ctx, strings.TrimSpace(name), offset, limit)
+}
+
+func (service *Service) GetArtist(ctx context.Context, id uint64) (*martist.Artist, error) {
+	return service.artistRepository.GetByID(ctx, id)
+}
+
+func (service *Service) CreateArtist(ctx context.Context, artist martist.Artist) (uint64, error) {
+	return service.artistRepository.Create(ctx, normalizeArtist(artist))
+}
+
+func (service *Service) UpdateArtist(ctx context.Context, artist martist.Artist) error {
+	return service.artistRepository.Update(ctx, normalizeArtist(artist))
+}
+
+func (service *Service) DeleteArtist(ctx context.Context, id uint64) error {
+	return service.artistRepository.Delete(ctx, id)
+}
+
+// GetArtistSongs lists the songs of an existing artist.
+func (service *Service) GetArtistSongs(ctx context.Context, id uint64, page msong.Page) (*msong.SongPage, error) {
+	if _, err := service.artistRepository.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filter := msong.Filter{}.And("artistId", msong.OpEq, strconv.FormatUint(id, 10))
+
+	return service.songRepository.GetPaginatedSongs(ctx, filter, page)
+}
+
+// normalizeArtist trims the names and drops empty aliases, repeated ones
+// and those equal to the name.
+func normalizeArtist(artist martist.Artist) martist.Artist {
+	artist.Name = strings.TrimSpace(artist.Name)
+	artist.SortName = strings.TrimSpace(artist.SortName)
+
+	seen := map[string]bool{strings.ToLower(artist.Name): true}
+	aliases := make([]string, 0, len(artist.Aliases))
+
+	for _, alias := range artist.Aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+
+	artist.Aliases = aliases
+
+	return artist
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	martist "online-song-library/internal/model/artist"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/service"
+
+	"go.uber.org/mock/gomock"
+)
+
+var muse = martist.Artist{ID: 3, Name: "Muse", SortName: "Muse", Aliases: []string{"Rocket Baby Dolls"}}
+
+var artistTests = []serviceTest{
+	{
+		name: "ListArtists trims the name",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().
+				List(gomock.Any(), "mus", 1, 10).
+				Return(&martist.ArtistPage{Artists: []martist.Artist{muse}, Total: 1}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.ListArtists(ctx, "  mus ", 1, 10)
+		},
+		want: &martist.ArtistPage{Artists: []martist.Artist{muse}, Total: 1},
+	},
+	{
+		name: "GetArtist",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().GetByID(gomock.Any(), uint64(3)).Return(&muse, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetArtist(ctx, 3)
+		},
+		want: &muse,
+	},
+	{
+		name: "CreateArtist normalizes names and aliases",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().
+				Create(gomock.Any(), martist.Artist{Name: "Muse", SortName: "Muse", Aliases: []string{"Rocket Baby Dolls"}}).
+				Return(uint64(3), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateArtist(ctx, martist.Artist{
+				Name:     " Muse ",
+				SortName: "Muse ",
+				Aliases:  []string{"", "muse", " Rocket Baby Dolls", "rocket baby dolls"},
+			})
+		},
+		want: uint64(3),
+	},
+	{
+		name: "UpdateArtist normalizes names and aliases",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().
+				Update(gomock.Any(), martist.Artist{ID: 3, Name: "Muse", Aliases: []string{}}).
+				Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.UpdateArtist(ctx, martist.Artist{ID: 3, Name: "Muse\t", Aliases: []string{"MUSE"}})
+		},
+	},
+	{
+		name: "DeleteArtist",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().Delete(gomock.Any(), uint64(3)).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.DeleteArtist(ctx, 3)
+		},
+	},
+	{
+		name: "GetArtistSongs",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().GetByID(gomock.Any(), uint64(3)).Return(&muse, nil)
+			f.songs.EXPECT().
+				GetPaginatedSongs(gomock.Any(), msong.Filter{}.And("artistId", msong.OpEq, "3"), firstPage).
+				Return(musePage, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetArtistSongs(ctx, 3, firstPage)
+		},
+		want: musePage,
+	},
+	{
+		name: "GetArtistSongs of an unknown artist",
+		setup: func(f *fixture) {
+			f.artists.EXPECT().GetByID(gomock.Any(), uint64(4)).Return(nil, martist.ErrNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetArtistSongs(ctx, 4, firstPage)
+		},
+		wantErr: martist.ErrNotFound,
+	},
+}
+
+func TestArtists(t *testing.T) {
+	runServiceTests(t, artistTests)
+}
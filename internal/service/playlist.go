@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	mplaylist "online-song-library/internal/model/playlist"
+)
+
+var errAmbiguousPlacement = apperror.New(apperror.ErrValidation, "only one of before and after can be set")
+
+func (service *Service) ListPlaylists(
+	ctx context.Context,
+	owner string,
+	offset, limit int,
+) (*mplaylist.PlaylistPage, error) {
+	return service.playlistRepository.List(ctx, strings.TrimSpace(owner), offset, limit)
+}
+
+func (service *Service) GetPlaylist(ctx context.Context, id uint64) (*mplaylist.Details, error) {
+	return service.playlistRepository.GetByID(ctx, id)
+}
+
+func (service *Service) CreatePlaylist(ctx context.Context, playlist mplaylist.Playlist) (uint64, error) {
+	return service.playlistRepository.Create(ctx, normalizePlaylist(playlist))
+}
+
+func (service *Service) UpdatePlaylist(ctx context.Context, playlist mplaylist.Playlist) error {
+	return service.playlistRepository.Update(ctx, normalizePlaylist(playlist))
+}
+
+func (service *Service) DeletePlaylist(ctx context.Context, id uint64) error {
+	return service.playlistRepository.Delete(ctx, id)
+}
+
+// AddPlaylistItem puts the song on the playlist, at the end unless placement
+// names a neighbour. It returns the new item ID.
+func (service *Service) AddPlaylistItem(
+	ctx context.Context,
+	id, songID uint64,
+	placement mplaylist.Placement,
+) (uint64, error) {
+	if placement.Before != 0 && placement.After != 0 {
+		return 0, errAmbiguousPlacement
+	}
+
+	return service.playlistRepository.AddItem(ctx, id, songID, placement)
+}
+
+// MovePlaylistItem moves the item next to another one, to the end unless
+// placement names a neighbour.
+func (service *Service) MovePlaylistItem(
+	ctx context.Context,
+	id, itemID uint64,
+	placement mplaylist.Placement,
+) error {
+	if placement.Before != 0 && placement.After != 0 {
+		return errAmbiguousPlacement
+	}
+
+	return service.playlistRepository.MoveItem(ctx, id, itemID, placement)
+}
+
+func (service *Service) RemovePlaylistItem(ctx context.Context, id, itemID uint64) error {
+	return service.playlistRepository.RemoveItem(ctx, id, itemID)
+}
+
+func normalizePlaylist(playlist mplaylist.Playlist) mplaylist.Playlist {
+	playlist.Name = strings.TrimSpace(playlist.Name)
+	playlist.Owner = strings.TrimSpace(playlist.Owner)
+	playlist.Description = strings.TrimSpace(playlist.Description)
+
+	return playlist
+}
@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"online-song-library/internal/apperror"
+	mplaylist "online-song-library/internal/model/playlist"
+	"online-song-library/internal/service"
+
+	"go.uber.org/mock/gomock"
+)
+
+var workout = mplaylist.Playlist{ID: 9, Name: "Workout", Owner: "alex"}
+
+var playlistTests = []serviceTest{
+	{
+		name: "ListPlaylists trims the owner",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().
+				List(gomock.Any(), "alex", 1, 10).
+				Return(&mplaylist.PlaylistPage{Playlists: []mplaylist.Playlist{workout}, Total: 1}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.ListPlaylists(ctx, "alex ", 1, 10)
+		},
+		want: &mplaylist.PlaylistPage{Playlists: []mplaylist.Playlist{workout}, Total: 1},
+	},
+	{
+		name: "GetPlaylist",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().GetByID(gomock.Any(), uint64(9)).Return(&mplaylist.Details{Playlist: workout}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetPlaylist(ctx, 9)
+		},
+		want: &mplaylist.Details{Playlist: workout},
+	},
+	{
+		name: "CreatePlaylist trims the fields",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().
+				Create(gomock.Any(), mplaylist.Playlist{Name: "Workout", Owner: "alex", Description: "Fast songs"}).
+				Return(uint64(9), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreatePlaylist(ctx, mplaylist.Playlist{Name: " Workout", Owner: "alex ", Description: " Fast songs\n"})
+		},
+		want: uint64(9),
+	},
+	{
+		name: "UpdatePlaylist trims the fields",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().Update(gomock.Any(), workout).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.UpdatePlaylist(ctx, mplaylist.Playlist{ID: 9, Name: "Workout ", Owner: " alex"})
+		},
+	},
+	{
+		name: "DeletePlaylist",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().Delete(gomock.Any(), uint64(9)).Return(mplaylist.ErrNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.DeletePlaylist(ctx, 9)
+		},
+		wantErr: mplaylist.ErrNotFound,
+	},
+	{
+		name: "AddPlaylistItem",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().
+				AddItem(gomock.Any(), uint64(9), uint64(7), mplaylist.Placement{After: 12}).
+				Return(uint64(13), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.AddPlaylistItem(ctx, 9, 7, mplaylist.Placement{After: 12})
+		},
+		want: uint64(13),
+	},
+	{
+		name: "AddPlaylistItem before and after",
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.AddPlaylistItem(ctx, 9, 7, mplaylist.Placement{Before: 11, After: 12})
+		},
+		wantErr: apperror.ErrValidation,
+	},
+	{
+		name: "MovePlaylistItem",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().MoveItem(gomock.Any(), uint64(9), uint64(13), mplaylist.Placement{Before: 11}).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.MovePlaylistItem(ctx, 9, 13, mplaylist.Placement{Before: 11})
+		},
+	},
+	{
+		name: "MovePlaylistItem before and after",
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.MovePlaylistItem(ctx, 9, 13, mplaylist.Placement{Before: 11, After: 12})
+		},
+		wantErr: apperror.ErrValidation,
+	},
+	{
+		name: "RemovePlaylistItem",
+		setup: func(f *fixture) {
+			f.playlists.EXPECT().RemoveItem(gomock.Any(), uint64(9), uint64(13)).Return(mplaylist.ErrItemNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.RemovePlaylistItem(ctx, 9, 13)
+		},
+		wantErr: mplaylist.ErrItemNotFound,
+	},
+}
+
+func TestPlaylists(t *testing.T) {
+	runServiceTests(t, playlistTests)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	malbum "online-song-library/internal/model/album"
+	martist "online-song-library/internal/model/artist"
+	mplaylist "online-song-library/internal/model/playlist"
+	msong "online-song-library/internal/model/song"
+)
+
+//go:generate mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+
+// SongRepository is the song storage used by Service.
+// *songrepository.SongRepository implements it.
+type SongRepository interface {
+	GetPaginatedSongs(ctx context.Context, filter msong.Filter, page msong.Page) (*msong.SongPage, error)
+	SearchSongs(
+		ctx context.Context,
+		query string,
+		filter msong.Filter,
+		offset, limit int,
+	) (*[]msong.SearchResult, error)
+	GetByID(ctx context.Context, id uint64) (*msong.Details, error)
+	GetPaginatedVerses(ctx context.Context, s msong.Song, offset, limit int) ([]msong.Verse, error)
+	Create(ctx context.Context, s msong.Song) (uint64, error)
+	Update(ctx context.Context, s msong.Song) (int, error)
+	Patch(ctx context.Context, id uint64, patch msong.Patch) (int, error)
+	Delete(ctx context.Context, s msong.Song) error
+	AttachTags(ctx context.Context, id uint64, names []string) (int, error)
+	DetachTag(ctx context.Context, id uint64, name string) (int, error)
+	TagCounts(ctx context.Context, filter msong.Filter) ([]msong.TagCount, error)
+	ImportSongs(ctx context.Context, songs []msong.Song) ([]uint64, error)
+	ExportSongs(
+		ctx context.Context,
+		filter msong.Filter,
+		sort msong.Sort,
+		withVerses bool,
+		fn func(s msong.Song) error,
+	) error
+}
+
+// ArtistRepository is the artist storage used by Service.
+// *artistrepository.ArtistRepository implements it.
+type ArtistRepository interface {
+	List(ctx context.Context, name string, offset, limit int) (*martist.ArtistPage, error)
+	GetByID(ctx context.Context, id uint64) (*martist.Artist, error)
+	Create(ctx context.Context, a martist.Artist) (uint64, error)
+	Update(ctx context.Context, a martist.Artist) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// AlbumRepository is the album storage used by Service.
+// *albumrepository.AlbumRepository implements it.
+type AlbumRepository interface {
+	List(ctx context.Context, artistID uint64, title string, offset, limit int) (*malbum.AlbumPage, error)
+	GetByID(ctx context.Context, id uint64) (*malbum.Album, error)
+	Create(ctx context.Context, a malbum.Album) (uint64, error)
+	Update(ctx context.Context, a malbum.Album) error
+	Delete(ctx context.Context, id uint64) error
+	Tracks(ctx context.Context, id uint64) ([]malbum.Track, error)
+	SetTrack(ctx context.Context, albumID uint64, track malbum.Track) error
+	RemoveTrack(ctx context.Context, albumID, songID uint64) error
+}
+
+// PlaylistRepository is the playlist storage used by Service.
+// *playlistrepository.PlaylistRepository implements it.
+type PlaylistRepository interface {
+	List(ctx context.Context, owner string, offset, limit int) (*mplaylist.PlaylistPage, error)
+	GetByID(ctx context.Context, id uint64) (*mplaylist.Details, error)
+	Create(ctx context.Context, p mplaylist.Playlist) (uint64, error)
+	Update(ctx context.Context, p mplaylist.Playlist) error
+	Delete(ctx context.Context, id uint64) error
+	AddItem(ctx context.Context, id, songID uint64, placement mplaylist.Placement) (uint64, error)
+	MoveItem(ctx context.Context, id, itemID uint64, placement mplaylist.Placement) error
+	RemoveItem(ctx context.Context, id, itemID uint64) error
+}
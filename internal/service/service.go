@@ -2,80 +2,171 @@ package service
 
 import (
 	"context"
-	"strings"
+	"fmt"
 
+	"online-song-library/internal/apperror"
 	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/metadata"
 	msong "online-song-library/internal/model/song"
-	"online-song-library/internal/repository/songrepository"
+	"online-song-library/internal/songio"
+	"online-song-library/pkg/circuitbreaker"
+)
+
+// FallbackPolicy decides what CreateSong does while the circuit breaker
+// around the music info service is open. It only applies when that service
+// is the sole metadata provider.
+type FallbackPolicy string
 
-	"github.com/sirupsen/logrus"
+const (
+	// FallbackFail rejects the song with an upstream unavailable error.
+	FallbackFail FallbackPolicy = "fail"
+	// FallbackEnrich queues the song anyway, it is enriched once the service is back.
+	FallbackEnrich FallbackPolicy = "enrich"
 )
 
+// ParseFallbackPolicy returns the policy named by value, FallbackFail when it is empty.
+func ParseFallbackPolicy(value string) (FallbackPolicy, error) {
+	switch policy := FallbackPolicy(value); policy {
+	case FallbackFail, FallbackEnrich:
+		return policy, nil
+	case "":
+		return FallbackFail, nil
+	default:
+		return "", fmt.Errorf("unknown music info fallback %q", value)
+	}
+}
+
 type Service struct {
-	songRepository *songrepository.SongRepository
-	client         *infoservice.Client
+	songRepository     SongRepository
+	artistRepository   ArtistRepository
+	albumRepository    AlbumRepository
+	playlistRepository PlaylistRepository
+	provider           metadata.MetadataProvider
+	fallback           FallbackPolicy
 }
 
+var errNoMusicInfoService = apperror.New(apperror.ErrNotFound, "music info service is not configured")
+
 func NewService(
-	songRepository *songrepository.SongRepository,
-	client *infoservice.Client,
+	songRepository SongRepository,
+	artistRepository ArtistRepository,
+	albumRepository AlbumRepository,
+	playlistRepository PlaylistRepository,
+	provider metadata.MetadataProvider,
+	fallback FallbackPolicy,
 ) *Service {
 	return &Service{
-		songRepository: songRepository,
-		client:         client,
+		songRepository:     songRepository,
+		artistRepository:   artistRepository,
+		albumRepository:    albumRepository,
+		playlistRepository: playlistRepository,
+		provider:           provider,
+		fallback:           fallback,
 	}
 }
 
 func (service *Service) GetPaginatedSongs(
 	ctx context.Context,
-	fields map[string]string,
-	offset, limit int,
-) (*[]msong.Song, error) {
+	filter msong.Filter,
+	page msong.Page,
+) (*msong.SongPage, error) {
 	return service.songRepository.GetPaginatedSongs(
 		ctx,
-		fields,
+		filter,
+		page,
+	)
+}
+
+func (service *Service) SearchSongs(
+	ctx context.Context,
+	query string,
+	filter msong.Filter,
+	offset, limit int,
+) (*[]msong.SearchResult, error) {
+	return service.songRepository.SearchSongs(
+		ctx,
+		query,
+		filter,
 		offset,
 		limit,
 	)
 }
 
-func (service *Service) GetPaginatedText(
+func (service *Service) GetSong(ctx context.Context, id uint64) (*msong.Details, error) {
+	return service.songRepository.GetByID(ctx, id)
+}
+
+func (service *Service) GetPaginatedVerses(
 	ctx context.Context,
 	song msong.Song,
 	offset, limit int,
-) (string, error) {
-	verses, err := service.songRepository.GetPaginatedText(
+) ([]msong.Verse, error) {
+	return service.songRepository.GetPaginatedVerses(
 		ctx,
 		song,
 		offset,
 		limit,
 	)
-	if err != nil {
-		return "", err
-	}
-
-	return strings.Join(verses, "\n\n"), nil
 }
 
-func (service *Service) CreateSong(ctx context.Context, song msong.Song) error {
-	songDetail, err := service.client.GetSongInfo(song)
-	if err != nil {
-		logrus.Error("unable to get SongDetail: ", err)
+// CreateSong stores the song right away and queues it for enrichment with
+// the details from the music info service. The group is resolved to an
+// artist by name or alias, a new artist is created for an unknown one.
+// It returns the new song ID.
+func (service *Service) CreateSong(ctx context.Context, song msong.Song) (uint64, error) {
+	if reporter, ok := service.provider.(metadata.StatusReporter); ok &&
+		service.fallback != FallbackEnrich &&
+		reporter.Status().Breaker.State == circuitbreaker.StateOpen {
+		return 0, infoservice.ErrCircuitOpen
 	}
 
 	return service.songRepository.Create(ctx, msong.Song{
-		Group:       song.Group,
-		Song:        song.Song,
-		ReleaseDate: songDetail["releaseDate"],
-		Verses:      msong.SplitIntoVerses(songDetail["text"]),
-		Link:        songDetail["link"],
+		Group:            song.Group,
+		Song:             song.Song,
+		Verses:           []string{},
+		EnrichmentStatus: msong.EnrichmentPending,
 	})
 }
 
-func (service *Service) UpdateSong(ctx context.Context, song msong.Song) error {
+func (service *Service) UpdateSong(ctx context.Context, song msong.Song) (int, error) {
 	return service.songRepository.Update(ctx, song)
 }
 
+func (service *Service) PatchSong(ctx context.Context, id uint64, patch msong.Patch) (int, error) {
+	return service.songRepository.Patch(ctx, id, patch)
+}
+
+// DeleteSong removes the song, which also takes it off albums and playlists.
 func (service *Service) DeleteSong(ctx context.Context, song msong.Song) error {
 	return service.songRepository.Delete(ctx, song)
 }
+
+// ImportSongs stores the songs read from reader, see songio.Import.
+func (service *Service) ImportSongs(
+	ctx context.Context,
+	reader songio.Reader,
+	enrich bool,
+) (*msong.ImportReport, error) {
+	return songio.Import(ctx, service.songRepository, reader, enrich)
+}
+
+// ExportSongs writes the songs matching filter to writer as they are read.
+// It does not close writer.
+func (service *Service) ExportSongs(
+	ctx context.Context,
+	filter msong.Filter,
+	sort msong.Sort,
+	writer songio.Writer,
+	withVerses bool,
+) error {
+	return service.songRepository.ExportSongs(ctx, filter, sort, withVerses, writer.Write)
+}
+
+func (service *Service) MusicInfoStatus() (infoservice.Status, error) {
+	status, ok := metadata.StatusOf(service.provider)
+	if !ok {
+		return infoservice.Status{}, errNoMusicInfoService
+	}
+
+	return status, nil
+}
@@ -0,0 +1,447 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"online-song-library/internal/apperror"
+	"online-song-library/internal/clients/infoservice"
+	"online-song-library/internal/metadata"
+	metadatamocks "online-song-library/internal/metadata/mocks"
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/service"
+	"online-song-library/internal/service/mocks"
+	"online-song-library/internal/songio"
+	"online-song-library/pkg/circuitbreaker"
+
+	"go.uber.org/mock/gomock"
+)
+
+var errDatabase = errors.New("connection refused")
+
+// fixture holds the fakes a Service is built from. Tests set expectations on
+// the mocks and may swap the provider or the fallback before the build.
+type fixture struct {
+	songs     *mocks.MockSongRepository
+	artists   *mocks.MockArtistRepository
+	albums    *mocks.MockAlbumRepository
+	playlists *mocks.MockPlaylistRepository
+	metadata  *metadatamocks.MockMetadataProvider
+
+	provider metadata.MetadataProvider
+	fallback service.FallbackPolicy
+}
+
+// serviceTest calls one service method. call returns the method result, nil
+// for methods that only return an error.
+type serviceTest struct {
+	name    string
+	setup   func(f *fixture)
+	call    func(ctx context.Context, s *service.Service) (any, error)
+	want    any
+	wantErr error
+}
+
+func runServiceTests(t *testing.T, tests []serviceTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			f := &fixture{
+				songs:     mocks.NewMockSongRepository(ctrl),
+				artists:   mocks.NewMockArtistRepository(ctrl),
+				albums:    mocks.NewMockAlbumRepository(ctrl),
+				playlists: mocks.NewMockPlaylistRepository(ctrl),
+				metadata:  metadatamocks.NewMockMetadataProvider(ctrl),
+				fallback:  service.FallbackFail,
+			}
+			f.provider = f.metadata
+
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			s := service.NewService(f.songs, f.artists, f.albums, f.playlists, f.provider, f.fallback)
+
+			got, err := tt.call(context.Background(), s)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// infoClient is a provider backed by the music info service, reporting the
+// given breaker state.
+type infoClient struct {
+	*metadatamocks.MockMetadataProvider
+	state circuitbreaker.State
+}
+
+func (c infoClient) Status() infoservice.Status {
+	return infoservice.Status{
+		Breaker:       circuitbreaker.Status{State: c.state},
+		MaxConcurrent: 10,
+	}
+}
+
+// withInfoClient makes the music info service the provider of the service.
+func withInfoClient(state circuitbreaker.State, fallback service.FallbackPolicy) func(f *fixture) {
+	return func(f *fixture) {
+		f.provider = infoClient{MockMetadataProvider: f.metadata, state: state}
+		f.fallback = fallback
+	}
+}
+
+var (
+	hysteria  = msong.Song{ID: 7, Group: "Muse", Song: "Hysteria", ReleaseDate: "01.12.2003"}
+	musePage  = &msong.SongPage{Songs: []msong.Song{hysteria}, Total: 1}
+	museOnly  = msong.Filter{}.And("group", msong.OpEq, "Muse")
+	firstPage = msong.Page{Offset: 1, Limit: 10}
+)
+
+var songTests = []serviceTest{
+	{
+		name: "GetPaginatedSongs",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().GetPaginatedSongs(gomock.Any(), museOnly, firstPage).Return(musePage, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetPaginatedSongs(ctx, museOnly, firstPage)
+		},
+		want: musePage,
+	},
+	{
+		name: "SearchSongs",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().
+				SearchSongs(gomock.Any(), "bugging me", museOnly, 1, 10).
+				Return(&[]msong.SearchResult{{Song: hysteria}}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.SearchSongs(ctx, "bugging me", museOnly, 1, 10)
+		},
+		want: &[]msong.SearchResult{{Song: hysteria}},
+	},
+	{
+		name: "GetSong",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().GetByID(gomock.Any(), uint64(7)).Return(&msong.Details{Song: hysteria}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetSong(ctx, 7)
+		},
+		want: &msong.Details{Song: hysteria},
+	},
+	{
+		name: "GetSong not found",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().GetByID(gomock.Any(), uint64(8)).Return(nil, msong.ErrNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetSong(ctx, 8)
+		},
+		wantErr: msong.ErrNotFound,
+	},
+	{
+		name: "GetPaginatedVerses",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().
+				GetPaginatedVerses(gomock.Any(), msong.Song{ID: 7}, 2, 1).
+				Return([]msong.Verse{{Index: 2, Text: "I want it now"}}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.GetPaginatedVerses(ctx, msong.Song{ID: 7}, 2, 1)
+		},
+		want: []msong.Verse{{Index: 2, Text: "I want it now"}},
+	},
+	{
+		name: "UpdateSong",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Update(gomock.Any(), hysteria).Return(4, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.UpdateSong(ctx, hysteria)
+		},
+		want: 4,
+	},
+	{
+		name: "UpdateSong stale version",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(0, msong.ErrVersionMismatch)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.UpdateSong(ctx, hysteria)
+		},
+		wantErr: msong.ErrVersionMismatch,
+	},
+	{
+		name: "PatchSong",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Patch(gomock.Any(), uint64(7), msong.Patch{Version: 3}).Return(4, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.PatchSong(ctx, 7, msong.Patch{Version: 3})
+		},
+		want: 4,
+	},
+	{
+		name: "DeleteSong",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Delete(gomock.Any(), msong.Song{ID: 7, Version: 3}).Return(nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.DeleteSong(ctx, msong.Song{ID: 7, Version: 3})
+		},
+	},
+	{
+		name: "DeleteSong error",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errDatabase)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return nil, s.DeleteSong(ctx, msong.Song{ID: 7})
+		},
+		wantErr: errDatabase,
+	},
+}
+
+func TestSongs(t *testing.T) {
+	runServiceTests(t, songTests)
+}
+
+// pending is the song CreateSong stores for hysteria.
+var pending = msong.Song{
+	Group:            "Muse",
+	Song:             "Hysteria",
+	Verses:           []string{},
+	EnrichmentStatus: msong.EnrichmentPending,
+}
+
+var createSongTests = []serviceTest{
+	{
+		name: "queued for enrichment",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Create(gomock.Any(), pending).Return(uint64(7), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		want: uint64(7),
+	},
+	{
+		name: "breaker closed",
+		setup: func(f *fixture) {
+			withInfoClient(circuitbreaker.StateClosed, service.FallbackFail)(f)
+			f.songs.EXPECT().Create(gomock.Any(), pending).Return(uint64(7), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		want: uint64(7),
+	},
+	{
+		name:  "breaker open fails",
+		setup: withInfoClient(circuitbreaker.StateOpen, service.FallbackFail),
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		wantErr: infoservice.ErrCircuitOpen,
+	},
+	{
+		name: "breaker open with enrich fallback",
+		setup: func(f *fixture) {
+			withInfoClient(circuitbreaker.StateOpen, service.FallbackEnrich)(f)
+			f.songs.EXPECT().Create(gomock.Any(), pending).Return(uint64(7), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		want: uint64(7),
+	},
+	{
+		name: "breaker half-open lets the song through",
+		setup: func(f *fixture) {
+			withInfoClient(circuitbreaker.StateHalfOpen, service.FallbackFail)(f)
+			f.songs.EXPECT().Create(gomock.Any(), pending).Return(uint64(7), nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		want: uint64(7),
+	},
+	{
+		name: "duplicate",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().Create(gomock.Any(), pending).Return(uint64(0), apperror.ErrConflict)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.CreateSong(ctx, hysteria)
+		},
+		wantErr: apperror.ErrConflict,
+	},
+}
+
+func TestCreateSong(t *testing.T) {
+	runServiceTests(t, createSongTests)
+}
+
+const importCSV = "group,song\n" +
+	"Muse,Hysteria\n" +
+	"muse,HYSTERIA\n" +
+	",Nameless\n" +
+	"Muse,Uprising\n"
+
+// importCounts summarizes an import report as accepted, rejected and duplicate rows.
+func importCounts(report *msong.ImportReport, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return [3]int{report.Accepted, report.Rejected, report.Duplicate}, nil
+}
+
+// songWriter records the songs written to it.
+type songWriter struct {
+	songs []msong.Song
+}
+
+func (w *songWriter) Write(song msong.Song) error {
+	w.songs = append(w.songs, song)
+
+	return nil
+}
+
+func (w *songWriter) Close() error {
+	return nil
+}
+
+var bulkTests = []serviceTest{
+	{
+		name: "ImportSongs",
+		setup: func(f *fixture) {
+			queued := func(name string) msong.Song {
+				return msong.Song{Group: "Muse", Song: name, Verses: []string{}, EnrichmentStatus: msong.EnrichmentPending}
+			}
+
+			f.songs.EXPECT().
+				ImportSongs(gomock.Any(), []msong.Song{queued("Hysteria"), queued("Uprising")}).
+				Return([]uint64{7, 0}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			reader, err := songio.NewReader(strings.NewReader(importCSV), songio.MediaCSV)
+			if err != nil {
+				return nil, err
+			}
+
+			return importCounts(s.ImportSongs(ctx, reader, true))
+		},
+		want: [3]int{1, 1, 2},
+	},
+	{
+		name: "ImportSongs error",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().ImportSongs(gomock.Any(), gomock.Any()).Return(nil, errDatabase)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			reader, err := songio.NewReader(strings.NewReader(importCSV), songio.MediaCSV)
+			if err != nil {
+				return nil, err
+			}
+
+			return importCounts(s.ImportSongs(ctx, reader, false))
+		},
+		wantErr: errDatabase,
+	},
+	{
+		name: "ExportSongs",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().
+				ExportSongs(gomock.Any(), museOnly, msong.Sort{{Field: "song"}}, true, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ msong.Filter, _ msong.Sort, _ bool, fn func(msong.Song) error) error {
+					return fn(hysteria)
+				})
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			writer := &songWriter{}
+			err := s.ExportSongs(ctx, museOnly, msong.Sort{{Field: "song"}}, writer, true)
+
+			return writer.songs, err
+		},
+		want: []msong.Song{hysteria},
+	},
+}
+
+func TestBulk(t *testing.T) {
+	runServiceTests(t, bulkTests)
+}
+
+var musicInfoStatusTests = []serviceTest{
+	{
+		name:  "music info service",
+		setup: withInfoClient(circuitbreaker.StateOpen, service.FallbackFail),
+		call: func(_ context.Context, s *service.Service) (any, error) {
+			return s.MusicInfoStatus()
+		},
+		want: infoservice.Status{Breaker: circuitbreaker.Status{State: circuitbreaker.StateOpen}, MaxConcurrent: 10},
+	},
+	{
+		name: "music info service in a chain",
+		setup: func(f *fixture) {
+			f.provider = metadata.NewChain(f.metadata, infoClient{MockMetadataProvider: f.metadata})
+		},
+		call: func(_ context.Context, s *service.Service) (any, error) {
+			return s.MusicInfoStatus()
+		},
+		want: infoservice.Status{MaxConcurrent: 10},
+	},
+	{
+		name: "no music info service",
+		call: func(_ context.Context, s *service.Service) (any, error) {
+			return s.MusicInfoStatus()
+		},
+		wantErr: apperror.ErrNotFound,
+	},
+}
+
+func TestMusicInfoStatus(t *testing.T) {
+	runServiceTests(t, musicInfoStatusTests)
+}
+
+func TestParseFallbackPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    service.FallbackPolicy
+		wantErr bool
+	}{
+		{value: "fail", want: service.FallbackFail},
+		{value: "enrich", want: service.FallbackEnrich},
+		{value: "", want: service.FallbackFail},
+		{value: "retry", wantErr: true},
+		{value: "Enrich", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := service.ParseFallbackPolicy(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFallbackPolicy(%q) = %q, %v, want %q, error %t", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
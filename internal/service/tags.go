@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	msong "online-song-library/internal/model/song"
+)
+
+// AttachTags tags the song and returns its version. Tags are matched
+// case-insensitively, new ones keep the spelling they are given in.
+func (service *Service) AttachTags(ctx context.Context, id uint64, names []string) (int, error) {
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			tags = append(tags, name)
+		}
+	}
+
+	return service.songRepository.AttachTags(ctx, id, tags)
+}
+
+func (service *Service) DetachTag(ctx context.Context, id uint64, name string) (int, error) {
+	return service.songRepository.DetachTag(ctx, id, strings.TrimSpace(name))
+}
+
+func (service *Service) TagCounts(ctx context.Context, filter msong.Filter) ([]msong.TagCount, error) {
+	return service.songRepository.TagCounts(ctx, filter)
+}
@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/service"
+
+	"go.uber.org/mock/gomock"
+)
+
+var tagTests = []serviceTest{
+	{
+		name: "AttachTags trims and drops repeated tags",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().AttachTags(gomock.Any(), uint64(7), []string{"Rock", "ballad"}).Return(4, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.AttachTags(ctx, 7, []string{" Rock", "ballad", "rock ", "BALLAD"})
+		},
+		want: 4,
+	},
+	{
+		name: "DetachTag trims the tag",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().DetachTag(gomock.Any(), uint64(7), "rock").Return(5, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.DetachTag(ctx, 7, " rock ")
+		},
+		want: 5,
+	},
+	{
+		name: "DetachTag the song does not have",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().DetachTag(gomock.Any(), uint64(7), "jazz").Return(0, msong.ErrTagNotFound)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.DetachTag(ctx, 7, "jazz")
+		},
+		wantErr: msong.ErrTagNotFound,
+	},
+	{
+		name: "TagCounts",
+		setup: func(f *fixture) {
+			f.songs.EXPECT().TagCounts(gomock.Any(), museOnly).Return([]msong.TagCount{{Name: "rock", Songs: 2}}, nil)
+		},
+		call: func(ctx context.Context, s *service.Service) (any, error) {
+			return s.TagCounts(ctx, museOnly)
+		},
+		want: []msong.TagCount{{Name: "rock", Songs: 2}},
+	},
+}
+
+func TestTags(t *testing.T) {
+	runServiceTests(t, tagTests)
+}
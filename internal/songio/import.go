@@ -0,0 +1,156 @@
+package songio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+)
+
+const importBatchSize = 500
+
+// Importer stores a batch of songs in one transaction and returns their IDs,
+// 0 for songs skipped as duplicates of existing ones or of earlier songs of
+// the batch.
+type Importer interface {
+	ImportSongs(ctx context.Context, songs []msong.Song) ([]uint64, error)
+}
+
+type pendingRow struct {
+	index int
+	song  msong.Song
+}
+
+// Import validates the songs from reader and stores the valid ones in
+// batches. A song is a duplicate if its name matches, ignoring case, a song
+// of the same artist that exists or is on an earlier row. Group spellings
+// the database takes for one artist, like "The Beatles" and "beatles", are
+// matched here; aliases are left to the importer. With enrich, songs missing
+// any detail are queued for enrichment. On an error that ends reading, the
+// batches stored so far stay imported and the report covers them.
+func Import(ctx context.Context, importer Importer, reader Reader, enrich bool) (*msong.ImportReport, error) {
+	report := &msong.ImportReport{
+		Rows: []msong.ImportRow{},
+	}
+	seen := map[string]bool{}
+	batch := make([]pendingRow, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		songs := make([]msong.Song, len(batch))
+		for i, pending := range batch {
+			songs[i] = pending.song
+		}
+
+		ids, err := importer.ImportSongs(ctx, songs)
+		if err != nil {
+			return err
+		}
+
+		for i, pending := range batch {
+			row := &report.Rows[pending.index]
+			row.ID = ids[i]
+			row.Status = msong.ImportAccepted
+
+			if ids[i] == 0 {
+				row.Status = msong.ImportDuplicate
+			}
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	err := func() error {
+		for {
+			rowNum, song, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				return flush()
+			}
+
+			var rowErr *RowError
+			if errors.As(err, &rowErr) {
+				report.Rows = append(report.Rows, msong.ImportRow{
+					Row:    rowNum,
+					Status: msong.ImportRejected,
+					Errors: []apperror.FieldError{{Field: "row", Message: rowErr.Err.Error()}},
+				})
+
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			row := msong.ImportRow{Row: rowNum}
+
+			key := songKey(song)
+
+			switch fieldErrs := msong.ValidateSong(song); {
+			case len(fieldErrs) > 0:
+				row.Status = msong.ImportRejected
+				row.Errors = fieldErrs
+			case seen[key]:
+				row.Status = msong.ImportDuplicate
+			default:
+				seen[key] = true
+
+				if enrich && (song.ReleaseDate == "" || len(song.Verses) == 0 || song.Link == "") {
+					song.EnrichmentStatus = msong.EnrichmentPending
+				}
+
+				batch = append(batch, pendingRow{index: len(report.Rows), song: song})
+			}
+
+			report.Rows = append(report.Rows, row)
+
+			if len(batch) == importBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}()
+
+	// Rows of a batch that failed to store have no status and are dropped.
+	rows := report.Rows[:0]
+	for _, row := range report.Rows {
+		switch row.Status {
+		case msong.ImportAccepted:
+			report.Accepted++
+		case msong.ImportRejected:
+			report.Rejected++
+		case msong.ImportDuplicate:
+			report.Duplicate++
+		default:
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	report.Rows = rows
+
+	return report, err
+}
+
+// leadingThe is the article artist_key in the database drops from a group.
+var leadingThe = regexp.MustCompile(`^the\s+`)
+
+// songKey identifies a song within an import, normalizing the group like
+// artist_key in the database: case, surrounding spaces and a leading "The"
+// do not matter.
+func songKey(song msong.Song) string {
+	group := leadingThe.ReplaceAllString(strings.ToLower(strings.Trim(song.Group, " ")), "")
+
+	return group + "\x00" + strings.ToLower(song.Song)
+}
@@ -0,0 +1,70 @@
+package songio_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	msong "online-song-library/internal/model/song"
+	"online-song-library/internal/songio"
+)
+
+// fakeImporter stores every song it is given under the next ID.
+type fakeImporter struct {
+	songs []msong.Song
+}
+
+func (fi *fakeImporter) ImportSongs(_ context.Context, songs []msong.Song) ([]uint64, error) {
+	ids := make([]uint64, len(songs))
+	for i, song := range songs {
+		fi.songs = append(fi.songs, song)
+		ids[i] = uint64(len(fi.songs))
+	}
+
+	return ids, nil
+}
+
+func TestImportDuplicatesInFile(t *testing.T) {
+	const input = "group,song\n" +
+		"The Beatles,Yesterday\n" +
+		"Beatles,Yesterday\n" +
+		"the  beatles,YESTERDAY\n" +
+		"Beatles,Help!\n" +
+		"Theatre of Tragedy,Machine\n" +
+		"atre of Tragedy,Machine\n"
+
+	reader, err := songio.NewReader(strings.NewReader(input), songio.MediaCSV)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	importer := &fakeImporter{}
+
+	report, err := songio.Import(context.Background(), importer, reader, false)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	got := make([]string, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		got = append(got, row.Status)
+	}
+
+	want := []string{
+		msong.ImportAccepted,
+		msong.ImportDuplicate,
+		msong.ImportDuplicate,
+		msong.ImportAccepted,
+		msong.ImportAccepted,
+		msong.ImportAccepted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Import() row statuses = %v, want %v", got, want)
+	}
+
+	if report.Accepted != 4 || report.Duplicate != 2 || len(importer.songs) != 4 {
+		t.Errorf("Import() accepted %d, duplicate %d, stored %d, want 4, 2, 4",
+			report.Accepted, report.Duplicate, len(importer.songs))
+	}
+}
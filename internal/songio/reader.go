@@ -0,0 +1,223 @@
+// Package songio reads and writes songs in the bulk exchange formats.
+package songio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+)
+
+// Media types of the supported formats.
+const (
+	MediaCSV    = "text/csv"
+	MediaNDJSON = "application/x-ndjson"
+)
+
+// maxLineSize caps an NDJSON line.
+const maxLineSize = 1 << 20
+
+var (
+	errUnsupportedFormat = apperror.New(apperror.ErrUnsupportedMedia,
+		"supported formats are text/csv and application/x-ndjson")
+	errMissingColumns = apperror.New(apperror.ErrBadRequest, `CSV header must have "group" and "song" columns`)
+)
+
+// RowError is a record that could not be decoded. Reading can go on after it.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader streams songs from an input. Next returns io.EOF after the last
+// song and a *RowError for a malformed record; any other error ends reading.
+type Reader interface {
+	Next() (row int, song msong.Song, err error)
+}
+
+// NewReader picks the reader for mediaType, e.g. a Content-Type header value.
+func NewReader(r io.Reader, mediaType string) (Reader, error) {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return nil, errUnsupportedFormat
+	}
+
+	switch base {
+	case MediaCSV:
+		return NewCSVReader(r)
+	case MediaNDJSON, "application/jsonl":
+		return NewNDJSONReader(r), nil
+	default:
+		return nil, errUnsupportedFormat
+	}
+}
+
+// CSVReader reads CSV with a header row naming the columns group, song,
+// releaseDate, text and link in any order. Other columns are ignored. Verses
+// in text are separated by blank lines.
+type CSVReader struct {
+	csv     *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errMissingColumns
+		}
+
+		return nil, apperror.New(apperror.ErrBadRequest, fmt.Sprintf("read CSV header: %v", err))
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["group"]; !ok {
+		return nil, errMissingColumns
+	}
+
+	if _, ok := columns["song"]; !ok {
+		return nil, errMissingColumns
+	}
+
+	return &CSVReader{
+		csv:     reader,
+		columns: columns,
+	}, nil
+}
+
+func (cr *CSVReader) Next() (int, msong.Song, error) {
+	record, err := cr.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, msong.Song{}, io.EOF
+	}
+
+	cr.row++
+
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return cr.row, msong.Song{}, &RowError{Row: cr.row, Err: parseErr.Err}
+		}
+
+		return cr.row, msong.Song{}, err
+	}
+
+	field := func(name string) string {
+		i, ok := cr.columns[strings.ToLower(name)]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	return cr.row, msong.Song{
+		Group:       field("group"),
+		Song:        field("song"),
+		ReleaseDate: field("releaseDate"),
+		Verses:      msong.SplitIntoVerses(field("text")),
+		Link:        field("link"),
+	}, nil
+}
+
+// NDJSONReader reads one JSON song per line, in the shape the API returns:
+// text is a list of verses, or a single string with verses separated by
+// blank lines. Blank lines are skipped.
+type NDJSONReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &NDJSONReader{
+		scanner: scanner,
+	}
+}
+
+type ndjsonSong struct {
+	Group       string          `json:"group"`
+	Song        string          `json:"song"`
+	ReleaseDate string          `json:"releaseDate"`
+	Text        json.RawMessage `json:"text"`
+	Link        string          `json:"link"`
+}
+
+func (nr *NDJSONReader) Next() (int, msong.Song, error) {
+	for nr.scanner.Scan() {
+		line := bytes.TrimSpace(nr.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		nr.row++
+
+		var raw ndjsonSong
+		if err := json.Unmarshal(line, &raw); err != nil {
+			return nr.row, msong.Song{}, &RowError{Row: nr.row, Err: err}
+		}
+
+		verses, err := decodeVerses(raw.Text)
+		if err != nil {
+			return nr.row, msong.Song{}, &RowError{Row: nr.row, Err: err}
+		}
+
+		return nr.row, msong.Song{
+			Group:       strings.TrimSpace(raw.Group),
+			Song:        strings.TrimSpace(raw.Song),
+			ReleaseDate: strings.TrimSpace(raw.ReleaseDate),
+			Verses:      verses,
+			Link:        strings.TrimSpace(raw.Link),
+		}, nil
+	}
+
+	if err := nr.scanner.Err(); err != nil {
+		return nr.row + 1, msong.Song{}, fmt.Errorf("read NDJSON: %w", err)
+	}
+
+	return 0, msong.Song{}, io.EOF
+}
+
+func decodeVerses(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return []string{}, nil
+	}
+
+	var verses []string
+	if err := json.Unmarshal(raw, &verses); err == nil {
+		return verses, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, errors.New("text must be a string or a list of strings")
+	}
+
+	return msong.SplitIntoVerses(text), nil
+}
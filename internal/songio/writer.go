@@ -0,0 +1,176 @@
+package songio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"online-song-library/internal/apperror"
+	msong "online-song-library/internal/model/song"
+)
+
+// Export formats.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatJSON   = "json"
+)
+
+const writeBufferSize = 32 * 1024
+
+var errUnknownFormat = apperror.New(apperror.ErrBadRequest, "format must be csv, ndjson or json")
+
+// Writer streams songs in an export format. Output is buffered, Close
+// flushes it and ends the document.
+type Writer interface {
+	Write(song msong.Song) error
+	Close() error
+}
+
+// NewWriter returns the writer for format. Verses are written only with
+// withVerses. The output can be read back with NewReader.
+func NewWriter(w io.Writer, format string, withVerses bool) (Writer, error) {
+	buf := bufio.NewWriterSize(w, writeBufferSize)
+
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(buf, withVerses)
+	case FormatNDJSON:
+		return &ndjsonWriter{buf: buf, enc: json.NewEncoder(buf), withVerses: withVerses}, nil
+	case FormatJSON:
+		return &jsonWriter{buf: buf, withVerses: withVerses}, nil
+	default:
+		return nil, errUnknownFormat
+	}
+}
+
+// ContentType returns the media type of an export format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return MediaCSV + "; charset=utf-8"
+	case FormatNDJSON:
+		return MediaNDJSON
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+type csvWriter struct {
+	buf        *bufio.Writer
+	csv        *csv.Writer
+	withVerses bool
+	record     []string
+}
+
+func newCSVWriter(buf *bufio.Writer, withVerses bool) (*csvWriter, error) {
+	cw := &csvWriter{
+		buf:        buf,
+		csv:        csv.NewWriter(buf),
+		withVerses: withVerses,
+	}
+
+	header := []string{"id", "group", "song", "releaseDate", "link"}
+	if withVerses {
+		header = append(header, "text")
+	}
+
+	if err := cw.csv.Write(header); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(song msong.Song) error {
+	cw.record = append(cw.record[:0],
+		strconv.FormatUint(song.ID, 10),
+		song.Group,
+		song.Song,
+		song.ReleaseDate,
+		song.Link,
+	)
+
+	if cw.withVerses {
+		cw.record = append(cw.record, strings.Join(song.Verses, "\n\n"))
+	}
+
+	return cw.csv.Write(cw.record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.csv.Flush()
+	if err := cw.csv.Error(); err != nil {
+		return err
+	}
+
+	return cw.buf.Flush()
+}
+
+type ndjsonWriter struct {
+	buf        *bufio.Writer
+	enc        *json.Encoder
+	withVerses bool
+}
+
+func (nw *ndjsonWriter) Write(song msong.Song) error {
+	return nw.enc.Encode(exported(song, nw.withVerses))
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nw.buf.Flush()
+}
+
+// jsonWriter writes a single JSON array, one song per line.
+type jsonWriter struct {
+	buf        *bufio.Writer
+	withVerses bool
+	written    bool
+}
+
+func (jw *jsonWriter) Write(song msong.Song) error {
+	sep := ",\n"
+	if !jw.written {
+		sep = "[\n"
+		jw.written = true
+	}
+
+	data, err := json.Marshal(exported(song, jw.withVerses))
+	if err != nil {
+		return err
+	}
+
+	if _, err := jw.buf.WriteString(sep); err != nil {
+		return err
+	}
+
+	_, err = jw.buf.Write(data)
+
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	end := "\n]\n"
+	if !jw.written {
+		end = "[]\n"
+	}
+
+	if _, err := jw.buf.WriteString(end); err != nil {
+		return err
+	}
+
+	return jw.buf.Flush()
+}
+
+// exported drops the verses unless they are requested, so the text field is
+// left out.
+func exported(song msong.Song, withVerses bool) msong.Song {
+	if !withVerses {
+		song.Verses = nil
+	}
+
+	return song
+}
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- +migrate StatementBegin
+CREATE FUNCTION songs_verses_tsvector(verses text []) RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT to_tsvector('simple', array_to_string(verses, ' '))
+$$;
+-- +migrate StatementEnd
+
+ALTER TABLE songs
+    ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (songs_verses_tsvector(verses)) STORED;
+
+CREATE INDEX songs_search_vector_idx ON songs USING gin (search_vector);
+-- +migrate Down
+DROP INDEX songs_search_vector_idx;
+ALTER TABLE songs DROP COLUMN search_vector;
+DROP FUNCTION songs_verses_tsvector(text []);
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE songs
+    ADD COLUMN created_at timestamptz not null default now(),
+    ADD COLUMN updated_at timestamptz not null default now();
+-- +migrate Down
+ALTER TABLE songs
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at;
@@ -0,0 +1,4 @@
+-- +migrate Up
+ALTER TABLE songs ADD COLUMN version integer not null default 1;
+-- +migrate Down
+ALTER TABLE songs DROP COLUMN version;
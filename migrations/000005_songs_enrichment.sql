@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE songs
+    ALTER COLUMN release_date DROP NOT NULL,
+    ADD COLUMN enrichment_status text not null default 'ready'
+        CHECK (enrichment_status IN ('pending', 'ready', 'failed'));
+-- +migrate Down
+ALTER TABLE songs DROP COLUMN enrichment_status;
+UPDATE songs SET release_date = 'epoch' WHERE release_date IS NULL;
+ALTER TABLE songs ALTER COLUMN release_date SET NOT NULL;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE enrichment_jobs (
+    id bigserial primary key,
+    song_id integer not null unique references songs (id) ON DELETE CASCADE,
+    attempts integer not null default 0,
+    run_at timestamptz not null default now(),
+    last_error text,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX enrichment_jobs_run_at_idx ON enrichment_jobs (run_at);
+-- +migrate Down
+DROP TABLE enrichment_jobs;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE song_info_cache (
+    key text primary key,
+    release_date text not null default '',
+    text text not null default '',
+    link text not null default '',
+    not_found boolean not null default false,
+    expires_at timestamptz not null
+);
+
+CREATE INDEX song_info_cache_expires_at_idx ON song_info_cache (expires_at);
+-- +migrate Down
+DROP TABLE song_info_cache;
@@ -0,0 +1,4 @@
+-- +migrate Up
+CREATE INDEX songs_group_song_lower_idx ON songs (lower("group"), lower(song));
+-- +migrate Down
+DROP INDEX songs_group_song_lower_idx;
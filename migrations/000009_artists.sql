@@ -0,0 +1,119 @@
+-- +migrate Up
+-- artist_key is what makes two spellings the same artist: case, surrounding
+-- spaces and a leading "The" do not matter.
+-- +migrate StatementBegin
+CREATE FUNCTION artist_key(name text) RETURNS text
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT regexp_replace(lower(btrim(name)), '^the\s+', '')
+$$;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE FUNCTION artist_sort_name(name text) RETURNS text
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT regexp_replace(btrim(name), '^(the)\s+(.+)$', '\2, \1', 'i')
+$$;
+-- +migrate StatementEnd
+
+CREATE TABLE artists (
+    id serial primary key,
+    name text not null,
+    sort_name text not null,
+    aliases text [] not null default '{}',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE UNIQUE INDEX artists_name_key_idx ON artists (artist_key(name));
+
+-- One artist per group, named after its most used spelling. The other
+-- spellings become aliases.
+INSERT INTO artists (name, sort_name, aliases)
+SELECT
+    name,
+    artist_sort_name(name),
+    array_remove(spellings, name)
+FROM (
+    SELECT
+        mode() WITHIN GROUP (ORDER BY btrim("group")) AS name,
+        array_agg(DISTINCT btrim("group")) AS spellings
+    FROM songs
+    GROUP BY artist_key("group")
+) AS spellings;
+
+-- resolve_artist finds the artist by name or alias, creating it if there is none.
+-- +migrate StatementBegin
+CREATE FUNCTION resolve_artist(artist_name text) RETURNS artists
+LANGUAGE plpgsql AS $$
+DECLARE
+    artist artists;
+BEGIN
+    SELECT * INTO artist
+    FROM artists
+    WHERE artist_key(name) = artist_key(artist_name)
+        OR artist_key(artist_name) IN (SELECT artist_key(alias) FROM unnest(aliases) AS alias)
+    ORDER BY artist_key(name) = artist_key(artist_name) DESC
+    LIMIT 1;
+
+    IF FOUND THEN
+        RETURN artist;
+    END IF;
+
+    INSERT INTO artists (name, sort_name)
+    VALUES (btrim(artist_name), artist_sort_name(artist_name))
+    ON CONFLICT DO NOTHING
+    RETURNING * INTO artist;
+
+    IF FOUND THEN
+        RETURN artist;
+    END IF;
+
+    -- Another transaction has just created it.
+    SELECT * INTO artist FROM artists WHERE artist_key(name) = artist_key(artist_name);
+
+    RETURN artist;
+END
+$$;
+-- +migrate StatementEnd
+
+-- Every write of a song group links the song to its artist and replaces the
+-- group with the artist name, whichever path the write comes from.
+-- +migrate StatementBegin
+CREATE FUNCTION songs_resolve_artist() RETURNS trigger
+LANGUAGE plpgsql AS $$
+DECLARE
+    artist artists;
+BEGIN
+    artist := resolve_artist(NEW."group");
+    NEW.artist_id := artist.id;
+    NEW."group" := artist.name;
+
+    RETURN NEW;
+END
+$$;
+-- +migrate StatementEnd
+
+ALTER TABLE songs ADD COLUMN artist_id integer REFERENCES artists (id);
+
+UPDATE songs
+SET
+    artist_id = artists.id,
+    "group" = artists.name
+FROM artists
+WHERE artist_key(artists.name) = artist_key(songs."group");
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX songs_artist_id_idx ON songs (artist_id);
+
+CREATE TRIGGER songs_resolve_artist
+    BEFORE INSERT OR UPDATE OF "group" ON songs
+    FOR EACH ROW EXECUTE FUNCTION songs_resolve_artist();
+-- +migrate Down
+DROP TRIGGER songs_resolve_artist ON songs;
+DROP FUNCTION songs_resolve_artist();
+ALTER TABLE songs DROP COLUMN artist_id;
+DROP FUNCTION resolve_artist(text);
+DROP TABLE artists;
+DROP FUNCTION artist_sort_name(text);
+DROP FUNCTION artist_key(text);
@@ -0,0 +1,51 @@
+-- +migrate Up
+CREATE TABLE albums (
+    id serial primary key,
+    title text not null,
+    artist_id integer not null references artists (id),
+    release_date date,
+    link text not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE UNIQUE INDEX albums_artist_title_idx ON albums (artist_id, lower(title));
+
+CREATE TABLE album_tracks (
+    album_id integer not null references albums (id) ON DELETE CASCADE,
+    song_id integer not null references songs (id) ON DELETE CASCADE,
+    disc integer not null default 1 check (disc > 0),
+    track integer not null check (track > 0),
+    primary key (album_id, song_id),
+    unique (album_id, disc, track)
+);
+
+CREATE INDEX album_tracks_song_id_idx ON album_tracks (song_id);
+
+-- song_listing is what the song reads go through: a song without a release
+-- date of its own takes the earliest one of the albums it is on.
+CREATE VIEW song_listing AS
+SELECT
+    songs.id,
+    songs."group",
+    songs.song,
+    coalesce(songs.release_date, album_dates.release_date) AS release_date,
+    songs.verses,
+    songs.link,
+    songs.search_vector,
+    songs.artist_id,
+    songs.enrichment_status,
+    songs.created_at,
+    songs.updated_at,
+    songs.version
+FROM songs
+LEFT JOIN LATERAL (
+    SELECT min(albums.release_date) AS release_date
+    FROM album_tracks
+    JOIN albums ON albums.id = album_tracks.album_id
+    WHERE album_tracks.song_id = songs.id
+) AS album_dates ON true;
+-- +migrate Down
+DROP VIEW song_listing;
+DROP TABLE album_tracks;
+DROP TABLE albums;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE tags (
+    id serial primary key,
+    name text not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE UNIQUE INDEX tags_name_idx ON tags (lower(name));
+
+CREATE TABLE song_tags (
+    song_id integer not null references songs (id) ON DELETE CASCADE,
+    tag_id integer not null references tags (id) ON DELETE CASCADE,
+    primary key (song_id, tag_id)
+);
+
+CREATE INDEX song_tags_tag_id_idx ON song_tags (tag_id);
+-- +migrate Down
+DROP TABLE song_tags;
+DROP TABLE tags;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE playlists (
+    id serial primary key,
+    name text not null,
+    owner text not null,
+    description text not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX playlists_owner_idx ON playlists (owner);
+
+-- Items are ordered by position. An item is moved by giving it a position
+-- between its new neighbours, so the other items keep theirs.
+CREATE TABLE playlist_items (
+    id serial primary key,
+    playlist_id integer not null references playlists (id) ON DELETE CASCADE,
+    song_id integer not null references songs (id) ON DELETE CASCADE,
+    position numeric not null,
+    added_at timestamptz not null default now(),
+    CONSTRAINT playlist_items_position_key UNIQUE (playlist_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX playlist_items_song_id_idx ON playlist_items (song_id);
+-- +migrate Down
+DROP TABLE playlist_items;
+DROP TABLE playlists;
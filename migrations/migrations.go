@@ -0,0 +1,7 @@
+// Package migrations embeds the sql-migrate files of this directory.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,153 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting trial calls through.
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the number of trial calls allowed while half-open.
+	HalfOpenMaxCalls int
+}
+
+type Status struct {
+	State    State      `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+}
+
+// Breaker is a consecutive-failures circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+
+	mu            sync.Mutex
+	state         State
+	failures      int
+	openedAt      time.Time
+	halfOpenCalls int
+}
+
+func New(settings Settings) *Breaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 1
+	}
+
+	if settings.HalfOpenMaxCalls < 1 {
+		settings.HalfOpenMaxCalls = 1
+	}
+
+	return &Breaker{
+		settings: settings,
+	}
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Record with its outcome.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if time.Since(b.openedAt) < b.settings.OpenTimeout {
+			return ErrOpen
+		}
+
+		b.state = StateHalfOpen
+		b.halfOpenCalls = 0
+	}
+
+	if b.state == StateHalfOpen {
+		if b.halfOpenCalls >= b.settings.HalfOpenMaxCalls {
+			return ErrOpen
+		}
+
+		b.halfOpenCalls++
+	}
+
+	return nil
+}
+
+func (b *Breaker) Record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns the current state. An open breaker whose OpenTimeout has
+// elapsed is reported as half-open, as the next Allow lets a trial call through.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := Status{
+		State:    b.currentState(),
+		Failures: b.failures,
+	}
+
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+
+	return status
+}
+
+func (b *Breaker) currentState() State {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.settings.OpenTimeout {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
@@ -0,0 +1,55 @@
+package circuitbreaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"online-song-library/pkg/circuitbreaker"
+)
+
+func TestBreakerReportsHalfOpenAfterTimeout(t *testing.T) {
+	const openTimeout = 20 * time.Millisecond
+
+	breaker := circuitbreaker.New(circuitbreaker.Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      openTimeout,
+		HalfOpenMaxCalls: 1,
+	})
+
+	for range 2 {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+
+		breaker.Record(false)
+	}
+
+	if got := breaker.Status().State; got != circuitbreaker.StateOpen {
+		t.Fatalf("Status().State = %s after the threshold, want %s", got, circuitbreaker.StateOpen)
+	}
+
+	if err := breaker.Allow(); !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Fatalf("Allow() error = %v while open, want %v", err, circuitbreaker.ErrOpen)
+	}
+
+	time.Sleep(openTimeout)
+
+	if got := breaker.State(); got != circuitbreaker.StateHalfOpen {
+		t.Errorf("State() = %s after OpenTimeout without calls, want %s", got, circuitbreaker.StateHalfOpen)
+	}
+
+	if got := breaker.Status().State; got != circuitbreaker.StateHalfOpen {
+		t.Errorf("Status().State = %s after OpenTimeout without calls, want %s", got, circuitbreaker.StateHalfOpen)
+	}
+
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() error = %v for the trial call", err)
+	}
+
+	breaker.Record(true)
+
+	if got := breaker.State(); got != circuitbreaker.StateClosed {
+		t.Errorf("State() = %s after a successful trial call, want %s", got, circuitbreaker.StateClosed)
+	}
+}
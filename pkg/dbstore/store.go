@@ -17,3 +17,9 @@ type TxBeginner interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 	BeginTx(ctx context.Context, isoLevel pgx.TxOptions) (pgx.Tx, error)
 }
+
+// DB is a store that can also start transactions, e.g. *pgxpool.Pool.
+type DB interface {
+	Store
+	TxBeginner
+}
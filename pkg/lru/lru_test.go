@@ -0,0 +1,100 @@
+package lru_test
+
+import (
+	"testing"
+	"time"
+
+	"online-song-library/pkg/lru"
+)
+
+func TestEviction(t *testing.T) {
+	cache := lru.New[string, int](2)
+
+	cache.Set("a", 1, time.Hour)
+	cache.Set("b", 2, time.Hour)
+
+	// Reading a makes b the least recently used entry.
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Get(a) missed")
+	}
+
+	cache.Set("c", 3, time.Hour)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) hit, want it evicted")
+	}
+
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := cache.Get(key); !ok || got != want {
+			t.Errorf("Get(%s) = %d, %t, want %d, true", key, got, ok, want)
+		}
+	}
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestSetReplaces(t *testing.T) {
+	cache := lru.New[string, int](2)
+
+	cache.Set("a", 1, time.Hour)
+	cache.Set("b", 2, time.Hour)
+	cache.Set("a", 10, time.Hour)
+
+	// Replacing a makes b the least recently used entry.
+	cache.Set("c", 3, time.Hour)
+
+	if got, ok := cache.Get("a"); !ok || got != 10 {
+		t.Errorf("Get(a) = %d, %t, want 10, true", got, ok)
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) hit, want it evicted")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	const ttl = 20 * time.Millisecond
+
+	cache := lru.New[string, int](2)
+
+	cache.Set("short", 1, ttl)
+	cache.Set("long", 2, time.Hour)
+
+	if _, ok := cache.Get("short"); !ok {
+		t.Fatal("Get(short) missed before its TTL")
+	}
+
+	time.Sleep(2 * ttl)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Error("Get(short) hit after its TTL")
+	}
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want the expired entry dropped", got)
+	}
+
+	// Setting an entry again restarts its TTL.
+	cache.Set("short", 3, time.Hour)
+
+	if got, ok := cache.Get("short"); !ok || got != 3 {
+		t.Errorf("Get(short) = %d, %t, want 3, true", got, ok)
+	}
+}
+
+func TestMinimumSize(t *testing.T) {
+	cache := lru.New[string, int](0)
+
+	cache.Set("a", 1, time.Hour)
+	cache.Set("b", 2, time.Hour)
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+
+	if got, ok := cache.Get("b"); !ok || got != 2 {
+		t.Errorf("Get(b) = %d, %t, want 2, true", got, ok)
+	}
+}